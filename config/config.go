@@ -1,12 +1,20 @@
 package config
 
+import (
+	"context"
+	"errors"
+	"github.com/IBAX-io/go-ibax-sdk/packages/keysource"
+	"github.com/IBAX-io/go-ibax-sdk/packages/signer"
+)
+
 // Config
 // If you want to modify the configuration, you need to pay attention to the multi-threaded call problem, it is recommended to use the SetConfig() method
 type Config struct {
 	PrivateKey      string `json:"private_key" yaml:"private_key"` // private key.Do not use clear text. You can set the environment variable. The key controls access to your funds!
 	PublicKey       []byte `json:"public_key" yaml:"-"`            // public key
 	Ecosystem       int64  `json:"ecosystem" yaml:"ecosystem"`     // Login ecosystem Id.
-	ApiAddress      string `json:"api_address" yaml:"api_address"` // api address. Restful Api address or RPC api address. depends on enable_rpc
+	RoleId          int64  `json:"role_id" yaml:"role_id"`         // Login role Id of AutoLogin, 0 for no role
+	ApiAddress      string `json:"api_address" yaml:"api_address"` // api address. Restful Api address or RPC api address(http or ws). depends on enable_rpc
 	ApiPath         string `json:"api_path" yaml:"api_path"`       // api path. Restful Api Path
 	JwtPrefix       string `json:"jwt_prefix" yaml:"jwt_prefix"`   // jwt prefix
 	Token           string `json:"token" yaml:"-"`                 // token
@@ -18,6 +26,49 @@ type Config struct {
 	Cryptoer        string `json:"cryptoer" yaml:"cryptoer"`       // cryptoer
 	Hasher          string `json:"hasher" yaml:"hasher"`           // hasher crypto
 	EnableRpc       bool   `json:"enable_rpc" yaml:"enable_rpc"`   // enable rpc
+
+	ApiAddresses []string `json:"api_addresses" yaml:"api_addresses"` // more api addresses of the same network, requests fail over between them and api_address
+
+	KeySource keysource.Source `json:"-" yaml:"-"` // provides the private key at signing time instead of PrivateKey, the key is not kept in the Config
+	Signer    signer.Signer    `json:"-" yaml:"-"` // signs the logins and the transactions instead of PrivateKey and KeySource, the key may be kept by another process
+}
+
+// ErrNoKey is returned by Key if neither KeySource nor PrivateKey is set
+var ErrNoKey = errors.New("private key is not configured")
+
+// Key returns the private key of KeySource, else the one of PrivateKey
+func (c Config) Key(ctx context.Context) ([]byte, error) {
+	if c.KeySource != nil {
+		return c.KeySource.PrivateKey(ctx)
+	}
+	if c.PrivateKey == "" {
+		return nil, ErrNoKey
+	}
+	return keysource.DecodeHex(c.PrivateKey)
+}
+
+// HasKey reports whether Key can provide a private key
+func (c Config) HasKey() bool {
+	return c.KeySource != nil || c.PrivateKey != ""
+}
+
+// CanSign reports whether the logins and the transactions can be signed, by Signer or by the private key
+func (c Config) CanSign() bool {
+	return c.Signer != nil || c.HasKey()
+}
+
+// Endpoints returns ApiAddress followed by ApiAddresses, without duplicates
+func (c Config) Endpoints() []string {
+	var list []string
+	seen := make(map[string]bool)
+	for _, v := range append([]string{c.ApiAddress}, c.ApiAddresses...) {
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		list = append(list, v)
+	}
+	return list
 }
 
 // Version SDK Version
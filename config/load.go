@@ -0,0 +1,231 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/IBAX-io/go-ibax-sdk/packages/keysource"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// EnvPrefix prefixes the environment variables overriding the fields of Config, IBAX_ followed by the upper case
+// yaml name of the field, such as IBAX_API_ADDRESS. IBAX_API_ADDRESSES is a comma separated list
+const EnvPrefix = "IBAX_"
+
+// EnvProfile names the environment variable selecting the profile of Load
+const EnvProfile = EnvPrefix + "PROFILE"
+
+// Load reads the config file at path, .json files as JSON and other files as YAML. The profile named by the
+// IBAX_PROFILE environment variable, else by the profile field of the file, overrides the top level fields.
+// The IBAX_* environment variables override the file, an empty path loads them only. The result is validated,
+// see Validate
+func Load(path string) (Config, error) {
+	return LoadProfile(path, os.Getenv(EnvProfile))
+}
+
+// LoadProfile is like Load but selects profile, the profile field of the file if profile is empty
+//
+//	profile: testnet
+//	ecosystem: 1
+//	profiles:
+//	  local:
+//	    api_address: http://127.0.0.1:7079
+//	  testnet:
+//	    api_address: https://testnet.example.com
+//
+// The key_source field sets the KeySource, one of env, file, keystore with passphrase_env, or command. The relative
+// paths are relative to the directory of the file
+//
+//	key_source:
+//	  keystore: key.json
+//	  passphrase_env: IBAX_PASSPHRASE
+func LoadProfile(path, profile string) (Config, error) {
+	var c Config
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return Config{}, err
+		}
+		var ks *keySource
+		if strings.EqualFold(filepath.Ext(path), ".json") {
+			ks, err = decodeJSON(data, profile, &c)
+		} else {
+			ks, err = decodeYAML(data, profile, &c)
+		}
+		if err != nil {
+			return Config{}, fmt.Errorf("load config %s failed:%w", path, err)
+		}
+		if ks != nil {
+			if c.KeySource, err = ks.source(filepath.Dir(path)); err != nil {
+				return Config{}, err
+			}
+		}
+	}
+	if err := c.applyEnv(os.LookupEnv); err != nil {
+		return Config{}, err
+	}
+	if err := c.Validate(); err != nil {
+		return Config{}, err
+	}
+	return c, nil
+}
+
+// keySource is the key_source field of a config file
+type keySource struct {
+	Env           string   `json:"env" yaml:"env"`
+	File          string   `json:"file" yaml:"file"`
+	Keystore      string   `json:"keystore" yaml:"keystore"`
+	PassphraseEnv string   `json:"passphrase_env" yaml:"passphrase_env"`
+	Command       []string `json:"command" yaml:"command"`
+}
+
+// source returns the keysource.Source of ks, the relative paths are joined to dir
+func (ks *keySource) source(dir string) (keysource.Source, error) {
+	var list []keysource.Source
+	if ks.Env != "" {
+		list = append(list, keysource.Env(ks.Env))
+	}
+	if ks.File != "" {
+		list = append(list, keysource.File(joinPath(dir, ks.File)))
+	}
+	if ks.Keystore != "" {
+		if ks.PassphraseEnv == "" {
+			return nil, &ValidationError{Problems: []FieldError{{Field: "key_source", Message: "keystore needs passphrase_env"}}}
+		}
+		list = append(list, keysource.KeystoreEnv(joinPath(dir, ks.Keystore), ks.PassphraseEnv))
+	}
+	if len(ks.Command) > 0 {
+		list = append(list, keysource.Command(ks.Command[0], ks.Command[1:]...))
+	}
+	if len(list) != 1 {
+		return nil, &ValidationError{Problems: []FieldError{{Field: "key_source", Message: "needs one of env, file, keystore or command"}}}
+	}
+	return list[0], nil
+}
+
+func joinPath(dir, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+func decodeYAML(data []byte, profile string, c *Config) (*keySource, error) {
+	var f struct {
+		Profile   string               `yaml:"profile"`
+		Profiles  map[string]yaml.Node `yaml:"profiles"`
+		KeySource *keySource           `yaml:"key_source"`
+	}
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return nil, err
+	}
+	if profile == "" {
+		profile = f.Profile
+	}
+	if profile == "" {
+		return f.KeySource, nil
+	}
+	node, ok := f.Profiles[profile]
+	if !ok {
+		return nil, fmt.Errorf("profile %s is not found", profile)
+	}
+	var p struct {
+		KeySource *keySource `yaml:"key_source"`
+	}
+	if err := node.Decode(&p); err != nil {
+		return nil, err
+	}
+	if p.KeySource != nil {
+		f.KeySource = p.KeySource
+	}
+	return f.KeySource, node.Decode(c)
+}
+
+func decodeJSON(data []byte, profile string, c *Config) (*keySource, error) {
+	var f struct {
+		Profile   string                     `json:"profile"`
+		Profiles  map[string]json.RawMessage `json:"profiles"`
+		KeySource *keySource                 `json:"key_source"`
+	}
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, err
+	}
+	if profile == "" {
+		profile = f.Profile
+	}
+	if profile == "" {
+		return f.KeySource, nil
+	}
+	raw, ok := f.Profiles[profile]
+	if !ok {
+		return nil, fmt.Errorf("profile %s is not found", profile)
+	}
+	var p struct {
+		KeySource *keySource `json:"key_source"`
+	}
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return nil, err
+	}
+	if p.KeySource != nil {
+		f.KeySource = p.KeySource
+	}
+	return f.KeySource, json.Unmarshal(raw, c)
+}
+
+// applyEnv sets the fields with a yaml name to the IBAX_* variables found by lookup
+func (c *Config) applyEnv(lookup func(key string) (string, bool)) error {
+	var problems []FieldError
+	v := reflect.ValueOf(c).Elem()
+	for k := 0; k < v.NumField(); k++ {
+		name, _, _ := strings.Cut(v.Type().Field(k).Tag.Get("yaml"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		key := EnvPrefix + strings.ToUpper(name)
+		s, ok := lookup(key)
+		if !ok {
+			continue
+		}
+		field := v.Field(k)
+		switch field.Kind() {
+		case reflect.String:
+			field.SetString(s)
+		case reflect.Int64:
+			n, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				problems = append(problems, FieldError{Field: key, Message: "is not an integer:" + s})
+				continue
+			}
+			field.SetInt(n)
+		case reflect.Bool:
+			b, err := strconv.ParseBool(s)
+			if err != nil {
+				problems = append(problems, FieldError{Field: key, Message: "is not a boolean:" + s})
+				continue
+			}
+			field.SetBool(b)
+		case reflect.Slice:
+			var list []string
+			for _, item := range strings.Split(s, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					list = append(list, item)
+				}
+			}
+			field.Set(reflect.ValueOf(list))
+		}
+	}
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package config
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"github.com/IBAX-io/go-ibax-sdk/packages/signer"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const testKey = "ed9a1a8b7ca4f3b5d1a4d0e5e1c1d0b4f0c7a9e8d6b5a4c3b2a1f0e9d8c7b6a5"
+
+func writeFile(t *testing.T, name, data string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	yamlPath := writeFile(t, "ibax.yml", `
+profile: testnet
+ecosystem: 1
+api_path: /api/v2/
+jwt_prefix: "Bearer "
+cryptoer: ECC_Secp256k1
+hasher: KECCAK256
+profiles:
+  local:
+    api_address: http://127.0.0.1:7079
+  testnet:
+    api_address: https://testnet.example.com
+    api_addresses: [https://testnet2.example.com]
+`)
+	jsonPath := writeFile(t, "ibax.json", `{
+	"profile": "local",
+	"ecosystem": 1,
+	"api_path": "/api/v2/",
+	"jwt_prefix": "Bearer ",
+	"cryptoer": "ECC_Secp256k1",
+	"hasher": "KECCAK256",
+	"profiles": {
+		"local": {"api_address": "http://127.0.0.1:7079"},
+		"mainnet": {"api_address": "wss://mainnet.example.com", "enable_rpc": true}
+	}
+}`)
+	want := Config{Ecosystem: 1, ApiAddress: "https://testnet.example.com", ApiPath: "/api/v2/", JwtPrefix: "Bearer ",
+		Cryptoer: "ECC_Secp256k1", Hasher: "KECCAK256", ApiAddresses: []string{"https://testnet2.example.com"}}
+	c, err := Load(yamlPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(c, want) {
+		t.Errorf("want %+v, got %+v", want, c)
+	}
+
+	if c, err = LoadProfile(yamlPath, "local"); err != nil || c.ApiAddress != "http://127.0.0.1:7079" {
+		t.Errorf("want the local profile, got %+v %v", c, err)
+	}
+	if c, err = Load(jsonPath); err != nil || c.ApiAddress != "http://127.0.0.1:7079" || c.Hasher != "KECCAK256" {
+		t.Errorf("want the local profile of json, got %+v %v", c, err)
+	}
+	if _, err = LoadProfile(jsonPath, "devnet"); err == nil {
+		t.Error("expected an unknown profile to fail")
+	}
+
+	t.Setenv(EnvProfile, "mainnet")
+	t.Setenv("IBAX_PRIVATE_KEY", testKey)
+	t.Setenv("IBAX_ECOSYSTEM", "2")
+	t.Setenv("IBAX_API_ADDRESSES", "wss://mainnet2.example.com, wss://mainnet3.example.com")
+	if c, err = Load(jsonPath); err != nil {
+		t.Fatal(err)
+	}
+	if c.ApiAddress != "wss://mainnet.example.com" || !c.EnableRpc || c.PrivateKey != testKey || c.Ecosystem != 2 ||
+		!reflect.DeepEqual(c.ApiAddresses, []string{"wss://mainnet2.example.com", "wss://mainnet3.example.com"}) {
+		t.Errorf("want the mainnet profile overridden by the environment, got %+v", c)
+	}
+
+	t.Setenv("IBAX_ECOSYSTEM", "first")
+	var invalid *ValidationError
+	if _, err = Load(jsonPath); !errors.As(err, &invalid) || invalid.Problems[0].Field != "IBAX_ECOSYSTEM" {
+		t.Errorf("expected IBAX_ECOSYSTEM invalid, got %v", err)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	c := Config{ApiAddress: "ftp://node", ApiAddresses: []string{"http://"}, Cryptoer: "RSA", PrivateKey: "xyz", RoleId: -1}
+	var invalid *ValidationError
+	if err := c.Validate(); !errors.As(err, &invalid) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	var fields []string
+	for _, v := range invalid.Problems {
+		fields = append(fields, v.Field)
+	}
+	want := []string{"api_address", "api_addresses[0]", "api_path", "ecosystem", "role_id", "cryptoer", "private_key", "hasher"}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("want the problems of %v, got %s", want, invalid)
+	}
+
+	c = Config{ApiAddress: "ws://node:7079", Ecosystem: 1, ApiPath: "/api/v2/"}
+	if err := c.Validate(); err == nil {
+		t.Error("expected a websocket address without enable_rpc to fail")
+	}
+	c.EnableRpc = true
+	if err := c.Validate(); err != nil {
+		t.Error(err)
+	}
+	c.Cryptoer, c.Hasher, c.PrivateKey = "ECC_Secp256k1", "KECCAK256", "ab"
+	if err := c.Validate(); !errors.As(err, &invalid) || invalid.Problems[0].Field != "private_key" {
+		t.Errorf("expected a short private key to fail, got %v", err)
+	}
+	c.PrivateKey = testKey[1:]
+	if err := c.Validate(); err != nil {
+		t.Errorf("expected a key with the leading zero trimmed to be valid, got %v", err)
+	}
+
+	c.Signer = &signer.Remote{URL: "http://127.0.0.1:8400"}
+	c.Cryptoer, c.Hasher, c.PrivateKey = "", "", testKey
+	if err := c.Validate(); !errors.As(err, &invalid) || len(invalid.Problems) != 3 {
+		t.Errorf("expected the private key with the signer and the missing algorithms to fail, got %v", err)
+	}
+}
+
+func TestLoad_KeySource(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "key"), []byte(testKey), 0600); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "ibax.yml")
+	if err := os.WriteFile(path, []byte(`
+api_address: http://127.0.0.1:7079
+api_path: /api/v2/
+ecosystem: 1
+cryptoer: ECC_Secp256k1
+hasher: KECCAK256
+key_source:
+  env: TEST_IBAX_KEY
+profiles:
+  file:
+    key_source:
+      file: key
+  keystore:
+    key_source:
+      keystore: key.json
+`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TEST_IBAX_KEY", testKey)
+	for _, profile := range []string{"", "file"} {
+		c, err := LoadProfile(path, profile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		key, err := c.Key(context.Background())
+		if err != nil || hex.EncodeToString(key) != testKey {
+			t.Errorf("profile %q: want the key of the key source, got %x %v", profile, key, err)
+		}
+	}
+	var invalid *ValidationError
+	if _, err := LoadProfile(path, "keystore"); !errors.As(err, &invalid) || invalid.Problems[0].Field != "key_source" {
+		t.Errorf("expected a keystore without passphrase_env to fail, got %v", err)
+	}
+	t.Setenv("IBAX_PRIVATE_KEY", testKey)
+	if _, err := LoadProfile(path, ""); !errors.As(err, &invalid) || invalid.Problems[0].Field != "private_key" {
+		t.Errorf("expected private_key with a key source to fail, got %v", err)
+	}
+}
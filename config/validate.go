@@ -0,0 +1,124 @@
+package config
+
+import (
+	"fmt"
+	"github.com/IBAX-io/go-ibax-sdk/packages/keysource"
+	"github.com/IBAX-io/go-ibax/packages/common/crypto"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// FieldError is a problem of a field of Config, named by its yaml name or environment variable
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e FieldError) String() string {
+	return e.Field + " " + e.Message
+}
+
+// ValidationError reports all the problems found in a Config
+type ValidationError struct {
+	Problems []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	list := make([]string, len(e.Problems))
+	for k, v := range e.Problems {
+		list[k] = v.String()
+	}
+	return "invalid config: " + strings.Join(list, "; ")
+}
+
+// Validate checks the addresses, the ecosystem, the cryptoer, the hasher and the private key or signer,
+// all the problems are reported at once by a *ValidationError
+func (c Config) Validate() error {
+	var problems []FieldError
+	add := func(field, format string, args ...any) {
+		problems = append(problems, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+	if c.ApiAddress == "" {
+		add("api_address", "is missing")
+	} else if err := validateAddress(c.ApiAddress); err != nil {
+		add("api_address", "%s", err.Error())
+	} else if !c.EnableRpc && (strings.HasPrefix(c.ApiAddress, "ws://") || strings.HasPrefix(c.ApiAddress, "wss://")) {
+		add("api_address", "is a websocket address, it needs enable_rpc")
+	}
+	for k, v := range c.ApiAddresses {
+		if err := validateAddress(v); err != nil {
+			add(fmt.Sprintf("api_addresses[%d]", k), "%s", err.Error())
+		}
+	}
+	if !c.EnableRpc && c.ApiPath == "" {
+		add("api_path", "is missing")
+	}
+	if c.Ecosystem <= 0 {
+		add("ecosystem", "is missing")
+	}
+	if c.RoleId < 0 {
+		add("role_id", "is negative")
+	}
+	if c.Cryptoer != "" {
+		if _, ok := crypto.AsymAlgo_value[c.Cryptoer]; !ok {
+			add("cryptoer", "%s is unknown, want one of %s", c.Cryptoer, names(crypto.AsymAlgo_value))
+		}
+	}
+	if c.Hasher != "" {
+		if _, ok := crypto.HashAlgo_value[c.Hasher]; !ok {
+			add("hasher", "%s is unknown, want one of %s", c.Hasher, names(crypto.HashAlgo_value))
+		}
+	}
+	if c.PrivateKey != "" {
+		if _, err := keysource.DecodeHex(c.PrivateKey); err != nil {
+			add("private_key", "is malformed, want 64 hex characters")
+		}
+		if c.KeySource != nil {
+			add("private_key", "is set together with the key source")
+		}
+		if c.Signer != nil {
+			add("private_key", "is set together with the signer")
+		}
+	}
+	if c.KeySource != nil && c.Signer != nil {
+		add("key_source", "is set together with the signer")
+	}
+	if c.CanSign() {
+		if c.Cryptoer == "" {
+			add("cryptoer", "is missing, it is needed by the private key")
+		}
+		if c.Hasher == "" {
+			add("hasher", "is missing, it is needed by the private key")
+		}
+	}
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+func validateAddress(address string) error {
+	u, err := url.Parse(address)
+	if err != nil {
+		return fmt.Errorf("is not a url:%s", address)
+	}
+	switch u.Scheme {
+	case "http", "https", "ws", "wss":
+	default:
+		return fmt.Errorf("has the scheme %q, want http, https, ws or wss", u.Scheme)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("has no host:%s", address)
+	}
+	return nil
+}
+
+func names(values map[string]int32) string {
+	list := make([]string, 0, len(values))
+	for k := range values {
+		list = append(list, k)
+	}
+	sort.Strings(list)
+	return strings.Join(list, ", ")
+}
@@ -1,34 +1,18 @@
 package auth
 
 import (
+	"context"
 	"encoding/hex"
+	"fmt"
 	"github.com/IBAX-io/go-ibax-sdk/packages/modus"
 	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/converter"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/cryptoer"
 	"github.com/IBAX-io/go-ibax-sdk/packages/response"
-	"github.com/IBAX-io/go-ibax/packages/common/crypto"
 	"net/url"
 	"strconv"
 	"time"
 )
 
-type loginResult struct {
-	Token       string        `json:"token,omitempty"`
-	EcosystemID string        `json:"ecosystem_id,omitempty"`
-	KeyID       string        `json:"key_id,omitempty"`
-	Account     string        `json:"account,omitempty"`
-	NotifyKey   string        `json:"notify_key,omitempty"`
-	IsNode      bool          `json:"isnode"`
-	IsOwner     bool          `json:"isowner"`
-	IsCLB       bool          `json:"clb"`
-	Timestamp   string        `json:"timestamp,omitempty"`
-	Roles       []rolesResult `json:"roles,omitempty"`
-}
-
-type rolesResult struct {
-	RoleId   int64  `json:"role_id"`
-	RoleName string `json:"role_name"`
-}
-
 type auth struct {
 	base modus.Base
 }
@@ -38,9 +22,13 @@ func New(b modus.Base) modus.Authentication {
 }
 
 func (c *auth) GetUidResponse() (*response.GetUIDResult, error) {
+	return c.GetUidResponseContext(context.Background())
+}
+
+func (c *auth) GetUidResponseContext(ctx context.Context) (*response.GetUIDResult, error) {
 	var ret response.GetUIDResult
 
-	err := c.base.SendGet(`getuid`, nil, &ret)
+	err := c.base.SendGetContext(ctx, `getuid`, nil, &ret)
 	if err != nil {
 		return nil, err
 	}
@@ -49,13 +37,26 @@ func (c *auth) GetUidResponse() (*response.GetUIDResult, error) {
 }
 
 func (c *auth) GetAuthStatus() (*response.AuthStatusResponse, error) {
-	return nil, response.NotSupportError
+	return c.GetAuthStatusContext(context.Background())
+}
+
+func (c *auth) GetAuthStatusContext(ctx context.Context) (*response.AuthStatusResponse, error) {
+	var result response.AuthStatusResponse
+	err := c.base.SendGetContext(ctx, "auth/status", nil, &result)
+	if err != nil {
+		return &result, err
+	}
+	return &result, nil
 }
 
 func (c *auth) GetUid() error {
+	return c.GetUidContext(context.Background())
+}
+
+func (c *auth) GetUidContext(ctx context.Context) error {
 	var ret response.GetUIDResult
 
-	err := c.base.SendGet(`getuid`, nil, &ret)
+	err := c.base.SendGetContext(ctx, `getuid`, nil, &ret)
 	if err != nil {
 		return err
 	}
@@ -94,15 +95,26 @@ func (c *auth) GetUid() error {
 // Login
 // roleId : Get the role id through the keyinfo interface
 func (c *auth) Login(roleId int64) (err error) {
-	var (
-		sign []byte
-		ret  loginResult
-	)
+	return c.LoginContext(context.Background(), roleId)
+}
+
+func (c *auth) LoginContext(ctx context.Context, roleId int64) (err error) {
+	_, err = c.LoginResponseContext(ctx, roleId)
+	return
+}
+
+func (c *auth) LoginResponse(roleId int64) (*response.LoginResult, error) {
+	return c.LoginResponseContext(context.Background(), roleId)
+}
+
+func (c *auth) LoginResponseContext(ctx context.Context, roleId int64) (*response.LoginResult, error) {
+	var ret response.LoginResult
 
 	cnf := c.base.GetConfig()
-	sign, err = crypto.SignString(cnf.PrivateKey, "LOGIN"+strconv.FormatInt(cnf.NetworkId, 10)+cnf.UID)
+	forSign := []byte("LOGIN" + strconv.FormatInt(cnf.NetworkId, 10) + cnf.UID)
+	sign, err := c.base.Signer().Sign(ctx, cryptoer.OrGlobal(c.base.Crypto()).Hash(forSign))
 	if err != nil {
-		return
+		return nil, err
 	}
 
 	form := url.Values{"pubkey": {hex.EncodeToString(cnf.PublicKey)}, "signature": {hex.EncodeToString(sign)},
@@ -110,15 +122,57 @@ func (c *auth) Login(roleId int64) (err error) {
 	if roleId != 0 {
 		form.Set("role_id", strconv.FormatInt(roleId, 10))
 	}
-	err = c.base.SendPost(`login`, &form, &ret)
-	if err == nil {
-		cnf.Token = ret.Token
-		c.base.SetConfig(cnf)
+	err = c.base.SendPostContext(ctx, `login`, &form, &ret)
+	if err != nil {
+		return nil, err
 	}
-	return
+	cnf.Token = ret.Token
+	cnf.TokenExpireTime = response.TokenExpireTime(ret.Token)
+	//the role is kept for the next logins
+	cnf.RoleId = roleId
+	c.base.SetConfig(cnf)
+	return &ret, nil
+}
+
+func (c *auth) LoginAs(roleName string) (*response.LoginResult, error) {
+	return c.LoginAsContext(context.Background(), roleName)
+}
+
+func (c *auth) LoginAsContext(ctx context.Context, roleName string) (*response.LoginResult, error) {
+	roles, err := c.RolesContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range roles {
+		if v.Name == roleName {
+			return c.LoginResponseContext(ctx, v.RoleID())
+		}
+	}
+	return nil, fmt.Errorf("role %s %w in ecosystem %d", roleName, response.ErrNotFound, c.base.GetConfig().Ecosystem)
+}
+
+func (c *auth) Roles() ([]response.RoleInfo, error) {
+	return c.RolesContext(context.Background())
+}
+
+func (c *auth) RolesContext(ctx context.Context) ([]response.RoleInfo, error) {
+	var ret response.KeyInfoResult
+	cnf := c.base.GetConfig()
+	err := c.base.SendGetContext(ctx, `keyinfo/`+cnf.Account, nil, &ret)
+	if err != nil {
+		return nil, err
+	}
+	if eco := ret.Ecosystem(cnf.Ecosystem); eco != nil {
+		return eco.Roles, nil
+	}
+	return nil, nil
 }
 
 func (c *auth) AutoLogin() (err error) {
+	return c.AutoLoginContext(context.Background())
+}
+
+func (c *auth) AutoLoginContext(ctx context.Context) (err error) {
 	cnf := c.base.GetConfig()
 	if cnf.Token != "" {
 		if time.Unix(cnf.TokenExpireTime, 0).Sub(time.Now()) < time.Minute*10 {
@@ -128,12 +182,12 @@ func (c *auth) AutoLogin() (err error) {
 			return nil
 		}
 	}
-	err = c.GetUid()
+	err = c.GetUidContext(ctx)
 	if err == nil {
-		//default 0
-		err = c.Login(0)
+		//default 0, the role of the config
+		err = c.LoginContext(ctx, cnf.RoleId)
 		if err == nil {
-			err = c.GetUid()
+			err = c.GetUidContext(ctx)
 		}
 	}
 	return
@@ -1,29 +1,31 @@
 package base
 
 import (
-	"bytes"
+	"context"
 	"encoding/hex"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"github.com/IBAX-io/go-ibax-sdk/config"
+	"github.com/IBAX-io/go-ibax-sdk/packages/keysource"
+	"github.com/IBAX-io/go-ibax-sdk/packages/logger"
+	"github.com/IBAX-io/go-ibax-sdk/packages/metrics"
 	"github.com/IBAX-io/go-ibax-sdk/packages/modus"
 	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/converter"
-	"github.com/IBAX-io/go-ibax-sdk/packages/request"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/cryptoer"
 	"github.com/IBAX-io/go-ibax-sdk/packages/response"
+	"github.com/IBAX-io/go-ibax-sdk/packages/signer"
+	"github.com/IBAX-io/go-ibax-sdk/packages/tracing"
+	"github.com/IBAX-io/go-ibax-sdk/packages/transport"
 	"github.com/IBAX-io/go-ibax/packages/common/crypto"
 	"github.com/shopspring/decimal"
-	log "github.com/sirupsen/logrus"
-	"io"
-	"mime/multipart"
 	"net/http"
 	"net/url"
 	"os"
-	"strings"
 	"sync"
+	"time"
 )
 
-// PrivateToPublicHex returns the hex public key for the specified hex private key.
+// PrivateToPublicHex returns the hex public key for the specified hex private key, with the process-global algorithms of go-ibax
 func PrivateToPublicHex(hexkey string) (string, error) {
 	key, err := hex.DecodeString(hexkey)
 	if err != nil {
@@ -37,220 +39,285 @@ func PrivateToPublicHex(hexkey string) (string, error) {
 }
 
 type base struct {
-	lock   sync.RWMutex
-	config *config.Config
+	lock      sync.RWMutex
+	config    *config.Config
+	id        *uint64 // JSON-RPC id counter, shared with the login copies
+	transport *transport.Transport
+	crypto    cryptoer.Provider
+	signer    signer.Signer
 }
 
 func New(config config.Config) modus.Base {
-	b := &base{config: &config}
-	if err := b.Init(); err != nil {
-		log.Fatalf("new base init failed:%s\n", err.Error())
+	b, err := NewWithOptions(config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "new base init failed:%s\n", err.Error())
+		os.Exit(1)
 	}
 	return b
 }
 
+// NewWithOptions returns the init error instead of exiting the process
+func NewWithOptions(config config.Config, opts ...transport.Option) (modus.Base, error) {
+	o := transport.NewOptions(opts...)
+	b := &base{config: &config, id: new(uint64), transport: transport.New(o)}
+	if err := b.Init(); err != nil {
+		return nil, err
+	}
+	b.transport.SetAddress(config.ApiAddress)
+	b.transport.KeepSession(b.tokenExpiry)
+	if len(config.ApiAddresses) > 0 {
+		b.transport.SetPool(transport.NewPool(config.Endpoints(), b.maxBlockId, o))
+	}
+	return b, nil
+}
+
+type maxBlockResult struct {
+	MaxBlockID int64 `json:"max_block_id"`
+}
+
+// maxBlockId is the health probe of the node endpoints
+func (c *base) maxBlockId(ctx context.Context, endpoint string) (int64, error) {
+	var result maxBlockResult
+	err := c.doRequest(ctx, endpoint, "GET", "maxblockid", nil, &result)
+	return result.MaxBlockID, err
+}
+
+// Pool returns the node endpoint pool, nil if api_addresses is not configured
+func (c *base) Pool() *transport.Pool {
+	return c.transport.Pool()
+}
+
+// Metrics returns the metrics set by transport.WithMetrics, metrics.Nop if not set
+func (c *base) Metrics() metrics.Metrics {
+	return c.transport.Metrics()
+}
+
+// Logger returns the logger set by transport.WithLogger, logger.Nop if not set
+func (c *base) Logger() logger.Logger {
+	return c.transport.Logger()
+}
+
+// Tracer returns the tracer set by transport.WithTracer, tracing.Nop if not set
+func (c *base) Tracer() tracing.Tracer {
+	return c.transport.Tracer()
+}
+
+// Crypto returns the algorithms of the cryptoer and hasher of the config, nil if they are not configured
+func (c *base) Crypto() cryptoer.Provider {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.crypto
+}
+
+// Signer returns the signer of the config, else the one of the private key of the config
+func (c *base) Signer() signer.Signer {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.signer
+}
+
+// key returns the private key of the current config
+func (c *base) key(ctx context.Context) ([]byte, error) {
+	return c.GetConfig().Key(ctx)
+}
+
 func (c *base) Init() (err error) {
-	var (
-		key, pub []byte
-		pubStr   string
-	)
+	var pub []byte
 
 	c.lock.Lock()
 	defer c.lock.Unlock()
-	if c.config.PrivateKey == "" {
+	// the algorithms belong to the client, the process-global ones of go-ibax are not changed
+	c.crypto, c.signer = nil, nil
+	if c.config.Cryptoer != "" || c.config.Hasher != "" {
+		if c.crypto, err = cryptoer.New(c.config.Cryptoer, c.config.Hasher); err != nil {
+			return
+		}
+	}
+	// the key of a key source is read again at every signing, it is not kept
+	c.signer = signer.FromSource(c.crypto, keysource.Func(c.key))
+	if c.config.Signer != nil {
+		c.signer = c.config.Signer
+	}
+	if !c.config.CanSign() {
 		return nil
 	}
-	key = []byte(c.config.PrivateKey)
-	if len(key) > 64 {
-		key = key[:64]
+	if c.crypto == nil {
+		return fmt.Errorf("cryptoer and hasher are needed by the private key")
+	}
+	if len(c.config.PrivateKey) > 64 {
+		c.config.PrivateKey = c.config.PrivateKey[:64]
 	}
-	c.config.PrivateKey = string(key)
-
-	crypto.InitAsymAlgo(c.config.Cryptoer)
-	crypto.InitHashAlgo(c.config.Hasher)
 
-	pubStr, err = PrivateToPublicHex(string(key))
+	// the lock is held, the public key of the private key is derived from this config
+	pubSigner := c.config.Signer
+	if pubSigner == nil {
+		pubSigner = signer.FromSource(c.crypto, keysource.Func(c.config.Key))
+	}
+	pub, err = pubSigner.PublicKey(context.Background())
 	if err != nil {
 		return
 	}
-	pub, err = hex.DecodeString(pubStr)
 	c.config.PublicKey = pub
-	c.config.KeyId = crypto.Address(pub)
+	c.signer = signer.WithPublicKey(c.signer, pub)
+	c.config.KeyId = c.crypto.Address(pub)
 	c.config.Account = converter.AddressToString(c.config.KeyId)
 
 	return
 }
 
-func (c *base) sendRequest(method, url string, form *url.Values, result any) error {
-	return c.sendRawRequest(method, url, form, result)
+func (c *base) sendRequest(ctx context.Context, method, url string, form *url.Values, result any) error {
+	return c.sendRawRequest(ctx, method, url, form, result)
 }
 
 func (c *base) ExpediteValidator(expedite string) error {
 	//input Max unit expedite
 	if expedite == "" {
-		return errors.New("[expedite] is empty")
+		return fmt.Errorf("[expedite] is empty:%w", response.ErrInvalidParams)
 	}
 	d, err := decimal.NewFromString(expedite)
 	if err != nil {
-		return fmt.Errorf("[expedite] params invalid:%s,err:%s", expedite, err.Error())
+		return fmt.Errorf("[expedite] %w:%s,err:%s", response.ErrInvalidParams, expedite, err.Error())
 	}
 	mix := decimal.New(1, -12)
 	if d.LessThan(mix) || d.Mod(mix).GreaterThan(decimal.Zero) {
-		return fmt.Errorf("[expedite] inconsistent with the smallest reference unit and its integer multiples:%s", expedite)
+		return fmt.Errorf("[expedite] %w, inconsistent with the smallest reference unit and its integer multiples:%s", response.ErrInvalidParams, expedite)
 	}
 	return nil
 }
 
 func (c *base) AmountValidator(amount string) error {
 	if amount == "" {
-		return errors.New("[amount] is empty")
+		return fmt.Errorf("[amount] is empty:%w", response.ErrInvalidParams)
 	}
 	d, err := decimal.NewFromString(amount)
 	if err != nil {
-		return fmt.Errorf("[amount] params invalid:%s,err:%s", amount, err.Error())
+		return fmt.Errorf("[amount] %w:%s,err:%s", response.ErrInvalidParams, amount, err.Error())
 	}
 	mix := decimal.New(1, 0)
 	if d.LessThan(mix) || d.Mod(mix).GreaterThan(decimal.Zero) {
-		return fmt.Errorf("[amount] inconsistent with the smallest reference unit and its integer multiples:%s", amount)
+		return fmt.Errorf("[amount] %w, inconsistent with the smallest reference unit and its integer multiples:%s", response.ErrInvalidParams, amount)
 	}
 	return nil
 }
 
-func (c *base) sendRawRequest(method, url string, form *url.Values, result any) error {
-	client := &http.Client{}
-	var ioForm io.Reader
-	if form != nil {
-		ioForm = strings.NewReader(form.Encode())
-	}
-	cnf := c.GetConfig()
-	apiAddress := cnf.ApiAddress + cnf.ApiPath
-	req, err := http.NewRequest(method, apiAddress+url, ioForm)
-	if err != nil {
-		return err
-	}
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	if len(cnf.Token) > 0 {
-		req.Header.Set("Authorization", cnf.JwtPrefix+cnf.Token)
-	}
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return err
-	}
-
-	defer resp.Body.Close()
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		if resp.StatusCode == http.StatusUnauthorized {
-			c.config.Token = ""
-		}
-		return fmt.Errorf(`%d %s`, resp.StatusCode, strings.TrimSpace(string(data)))
-	}
-	if result != nil {
-		contentType := resp.Header.Get("Content-Type")
-		var isFileType bool
-		switch result.(type) {
-		case *request.FileType:
-			isFileType = true
-		}
-		if strings.Contains(contentType, "application/json") && !isFileType {
-			err = json.Unmarshal(data, result)
-			if err != nil {
-				return fmt.Errorf("json unmarshal failed:%s", err.Error())
-			}
-		} else {
-			switch vt := result.(type) {
-			case *string:
-				*vt = string(data)
-			case *[]byte:
-				*vt = data
-			case *interface{}:
-				*vt = interface{}(data)
-			case *request.FileType:
-				fileName := result.(*request.FileType).Name
-				if fileName != "" {
-					err = os.WriteFile(fileName, data, 0644)
-					if err != nil {
-						return err
-					}
-					*vt = request.FileType{fileName, contentType, ""}
-				} else {
-					*vt = request.FileType{"", contentType, string(data)}
-				}
-			default:
-				return fmt.Errorf("not supported TYPE:%T", vt)
-			}
-
-			//value = string(data)
-			//value1 := reflect.ValueOf(value).Elem()
-			//value1.SetString(string(data))
-		}
-	}
+func (c *base) sendRawRequest(ctx context.Context, method, url string, form *url.Values, result any) error {
+	call := &transport.Call{Backend: transport.BackendREST, Method: method, Name: url, Params: form, Result: result}
+	return c.transport.Intercept(ctx, call, func(ctx context.Context, call *transport.Call) error {
+		return c.transport.Retry(ctx, func(endpoint string) error {
+			return c.doRequest(ctx, endpoint, method, url, form, result)
+		})
+	})
+}
 
-	return nil
+// doRequest sends the request to endpoint, or to the api address if endpoint is empty
+func (c *base) doRequest(ctx context.Context, endpoint, method, url string, form *url.Values, result any) error {
+	return c.transport.SendForm(ctx, c.restTarget(method, endpoint, url), form, result)
 }
 
 func (c *base) SendMultipart(url string, files map[string][]byte, result any) error {
-	body := new(bytes.Buffer)
-	writer := multipart.NewWriter(body)
-	cnf := c.GetConfig()
+	return c.SendMultipartContext(context.Background(), url, files, result)
+}
 
-	for key, data := range files {
-		part, err := writer.CreateFormFile(key, key)
-		if err != nil {
-			return err
-		}
-		if _, err := part.Write(data); err != nil {
-			return err
-		}
-	}
+// SendMultipartContext
+// sendTx of a single transaction is sent again only after txstatus confirms the node has not received it,
+// the transactions sent together are not sent again
+func (c *base) SendMultipartContext(ctx context.Context, url string, files map[string][]byte, result any) error {
+	call := &transport.Call{Backend: transport.BackendREST, Method: http.MethodPost, Name: url, Params: files, Result: result}
+	return c.transport.Intercept(ctx, call, func(ctx context.Context, call *transport.Call) error {
+		return c.transport.RetryFiles(ctx, url == "sendTx", files, func(endpoint string) error {
+			return c.transport.SendFiles(ctx, c.restTarget(http.MethodPost, endpoint, url), files, result)
+		}, func(endpoint string, hashes []string) (bool, error) {
+			return c.txAbsent(ctx, endpoint, hashes)
+		})
+	})
+}
 
-	if err := writer.Close(); err != nil {
-		return err
+// txAbsent reports whether the node at endpoint has not found the transactions of hashes, it is only used for
+// a single one
+func (c *base) txAbsent(ctx context.Context, endpoint string, hashes []string) (bool, error) {
+	data, err := json.Marshal(map[string][]string{"hashes": hashes})
+	if err != nil {
+		return false, err
 	}
+	return transport.TxAbsent(c.doRequest(ctx, endpoint, "POST", "txstatus", &url.Values{"data": {string(data)}}, nil))
+}
 
-	req, err := http.NewRequest("POST", cnf.ApiAddress+cnf.ApiPath+url, body)
-	if err != nil {
-		return err
+// restTarget returns the target of the request to url of the RESTful api of endpoint, or of the api address if
+// endpoint is empty
+func (c *base) restTarget(method, endpoint, url string) transport.Target {
+	cnf := c.GetConfig()
+	if endpoint == "" {
+		endpoint = cnf.ApiAddress
 	}
+	return c.target(cnf, method, endpoint+cnf.ApiPath+url)
+}
 
-	req.Header.Set("Content-Type", writer.FormDataContentType())
+// target returns the target of a request to url with the token of cnf, the token is dropped if the node rejects it
+func (c *base) target(cnf config.Config, method, url string) transport.Target {
+	target := transport.Target{Method: method, URL: url, Unauthorized: func() {
+		c.clearToken(cnf.Token)
+	}}
 	if len(cnf.Token) > 0 {
-		req.Header.Set("Authorization", cnf.JwtPrefix+cnf.Token)
+		target.Authorization = cnf.JwtPrefix + cnf.Token
 	}
+	return target
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
+func (c *base) SendGet(url string, form *url.Values, result any) error {
+	return c.SendGetContext(context.Background(), url, form, result)
+}
 
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
+func (c *base) SendGetContext(ctx context.Context, url string, form *url.Values, result any) error {
+	return c.sendRequest(ctx, "GET", url, form, result)
+}
 
-	if resp.StatusCode != http.StatusOK {
-		if resp.StatusCode == http.StatusUnauthorized {
-			c.config.Token = ""
-		}
-		return fmt.Errorf(`%d %s`, resp.StatusCode, strings.TrimSpace(string(data)))
+func (c *base) SendPost(url string, form *url.Values, result any) error {
+	return c.SendPostContext(context.Background(), url, form, result)
+}
+
+func (c *base) SendPostContext(ctx context.Context, url string, form *url.Values, result any) error {
+	return c.sendRequest(ctx, "POST", url, form, result)
+}
+
+// clearToken drops the token rejected by the node unless it has been replaced already
+func (c *base) clearToken(token string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.config.Token == token {
+		c.config.Token = ""
 	}
+}
 
-	return json.Unmarshal(data, &result)
+// SetReauth sets the login flow of transport.WithReauth and the session keeper. login runs on a copy of the base
+// without the token, so the requests sent meanwhile keep the current token. The token of the copy replaces it
+// only after the login succeeds
+func (c *base) SetReauth(login func(ctx context.Context, b modus.Base) error) {
+	c.transport.SetReauth(func(ctx context.Context) error {
+		lb := c.loginCopy()
+		if err := login(ctx, lb); err != nil {
+			return err
+		}
+		cnf := lb.GetConfig()
+		c.setToken(cnf.Token, cnf.TokenExpireTime)
+		return nil
+	})
 }
 
-func (c *base) SendGet(url string, form *url.Values, result any) error {
-	return c.sendRequest("GET", url, form, result)
+// loginCopy returns a base sharing the transport and the algorithms of c, with its own copy of the config without the token
+func (c *base) loginCopy() *base {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	cnf := *c.config
+	cnf.Token, cnf.TokenExpireTime = "", 0
+	return &base{config: &cnf, id: c.id, transport: c.transport, crypto: c.crypto, signer: c.signer}
 }
 
-func (c *base) SendPost(url string, form *url.Values, result any) error {
-	return c.sendRequest("POST", url, form, result)
+// setToken replaces the token and its expiry
+func (c *base) setToken(token string, expire int64) {
+	c.lock.Lock()
+	c.config.Token, c.config.TokenExpireTime = token, expire
+	c.lock.Unlock()
+	c.transport.TokenChanged()
 }
 
 func (c *base) GetConfig() config.Config {
@@ -261,26 +328,28 @@ func (c *base) GetConfig() config.Config {
 
 func (c *base) SetConfig(cnf config.Config) {
 	c.lock.Lock()
-	defer c.lock.Unlock()
+	changed := c.config.Token != cnf.Token || c.config.TokenExpireTime != cnf.TokenExpireTime
 	c.config = &cnf
+	c.lock.Unlock()
+	if changed {
+		c.transport.TokenChanged()
+	}
 }
 
-func (c *base) Version() string {
-	return config.Version
-}
-
-func (c *base) GET(form any, result any) error {
-	return response.NotSupportError
-}
-
-func (c *base) POST(form any, result any) error {
-	return response.NotSupportError
+// tokenExpiry returns the expiry of the token, zero if there is no token or its expiry is unknown
+func (c *base) tokenExpiry() time.Time {
+	cnf := c.GetConfig()
+	if cnf.Token == "" || cnf.TokenExpireTime == 0 {
+		return time.Time{}
+	}
+	return time.Unix(cnf.TokenExpireTime, 0)
 }
 
-func (c *base) NewMessage(params request.RequestParams) (request.Request, error) {
-	return request.Request{}, response.NotSupportError
+// Close stops the session keeper and closes the websocket connections to the nodes
+func (c *base) Close() error {
+	return c.transport.Close()
 }
 
-func (c *base) NewBatchMessage(requestPrams []request.BatchRequestParams) ([]request.BatchRequest, error) {
-	return []request.BatchRequest{}, response.NotSupportError
+func (c *base) Version() string {
+	return config.Version
 }
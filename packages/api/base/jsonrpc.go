@@ -0,0 +1,109 @@
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/IBAX-io/go-ibax-sdk/packages/request"
+	"github.com/IBAX-io/go-ibax-sdk/packages/transport"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+// The node serves the JSON-RPC api at the root path of the api address when it is enabled,
+// the messages of NewMessage and NewBatchMessage are sent there by GET and POST
+
+func (c *base) nextID() uint64 {
+	return atomic.AddUint64(c.id, 1)
+}
+
+func (c *base) makeRequest(params request.RequestParams) (request.Request, error) {
+	p, err := request.MakeParams(params.Params...)
+	if err != nil {
+		return request.Request{}, err
+	}
+	return request.Request{
+		JSONRPC: request.JsonRPCVersion,
+		ID:      request.ID{Num: c.nextID()},
+		Method:  string(params.Namespace) + request.NamespaceSeparator + params.Name,
+		Params:  p,
+	}, nil
+}
+
+func (c *base) NewMessage(params request.RequestParams) (request.Request, error) {
+	return c.makeRequest(params)
+}
+
+func (c *base) NewBatchMessage(requestPrams []request.BatchRequestParams) ([]request.BatchRequest, error) {
+	var batchRequest []request.BatchRequest
+	for _, params := range requestPrams {
+		req, err := c.makeRequest(params.RequestParams)
+		if err != nil {
+			return nil, err
+		}
+		batchRequest = append(batchRequest, request.BatchRequest{Req: &req, Result: params.Result})
+	}
+	return batchRequest, nil
+}
+
+func (c *base) GET(form any, result any) error {
+	return c.GETContext(context.Background(), form, result)
+}
+
+func (c *base) POST(form any, result any) error {
+	return c.POSTContext(context.Background(), form, result)
+}
+
+func (c *base) GETContext(ctx context.Context, form any, result any) error {
+	return c.sendRPCRequest(ctx, http.MethodGet, form, result)
+}
+
+func (c *base) POSTContext(ctx context.Context, form any, result any) error {
+	return c.sendRPCRequest(ctx, http.MethodPost, form, result)
+}
+
+// sendRPCRequest sends a JSON-RPC message, sendTx of a single transaction is sent again only after txStatus
+// confirms the node has not received it, the transactions sent together are not sent again
+func (c *base) sendRPCRequest(ctx context.Context, method string, msg any, result any) error {
+	call := &transport.Call{Backend: transport.BackendRPC, Method: method, Params: msg, Result: result}
+	switch m := msg.(type) {
+	case request.Request:
+		call.Name, call.Params = m.Method, m.Params
+	case []request.BatchRequest:
+		call.Name = "batch"
+	default:
+		return fmt.Errorf("the request structure does not support")
+	}
+	return c.transport.Intercept(ctx, call, func(ctx context.Context, call *transport.Call) error {
+		return c.transport.RetryMessage(ctx, msg, func(endpoint string) error {
+			return c.doRPCRequest(ctx, endpoint, method, msg, result)
+		}, func(endpoint string, hashes []string) (bool, error) {
+			return c.hashesAbsent(ctx, endpoint, hashes)
+		})
+	})
+}
+
+// hashesAbsent reports whether the node at endpoint has not found the transactions of hashes by JSON-RPC txStatus,
+// it is only used for a single one
+func (c *base) hashesAbsent(ctx context.Context, endpoint string, hashes []string) (bool, error) {
+	statusReq, err := c.makeRequest(request.RequestParams{
+		Namespace: request.NamespaceIBAX,
+		Name:      "txStatus",
+		Params:    []any{strings.Join(hashes, ",")},
+	})
+	if err != nil {
+		return false, err
+	}
+	var result map[string]json.RawMessage
+	return transport.TxAbsent(c.doRPCRequest(ctx, endpoint, http.MethodPost, statusReq, &result))
+}
+
+// doRPCRequest sends msg to the root path of endpoint, or of the api address if endpoint is empty
+func (c *base) doRPCRequest(ctx context.Context, endpoint, method string, msg any, result any) error {
+	cnf := c.GetConfig()
+	if endpoint == "" {
+		endpoint = cnf.ApiAddress
+	}
+	return c.transport.SendMessage(ctx, c.target(cnf, method, strings.TrimSuffix(endpoint, "/")+"/"), msg, result)
+}
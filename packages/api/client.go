@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"github.com/IBAX-io/go-ibax-sdk/config"
 	"github.com/IBAX-io/go-ibax-sdk/packages/api/auth"
 	"github.com/IBAX-io/go-ibax-sdk/packages/api/base"
@@ -9,6 +10,7 @@ import (
 	"github.com/IBAX-io/go-ibax-sdk/packages/api/tx/contract"
 	"github.com/IBAX-io/go-ibax-sdk/packages/api/tx/utxo"
 	"github.com/IBAX-io/go-ibax-sdk/packages/modus"
+	"github.com/IBAX-io/go-ibax-sdk/packages/transport"
 	"github.com/IBAX-io/go-ibax-sdk/packages/wallet"
 )
 
@@ -25,11 +27,34 @@ type client struct {
 
 func NewClient(config config.Config) modus.Client {
 	b := base.New(config)
+	return newClient(b)
+}
+
+// NewClientWithOptions creates a client with the transport options, the init error is returned instead of exiting the process
+func NewClientWithOptions(config config.Config, opts ...transport.Option) (modus.Client, error) {
+	b, err := base.NewWithOptions(config, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return newClient(b), nil
+}
+
+// reauthSetter is implemented by the base to log in again when the token is rejected
+type reauthSetter interface {
+	SetReauth(login func(ctx context.Context, b modus.Base) error)
+}
+
+func newClient(b modus.Base) modus.Client {
 	a := auth.New(b)
 	t := tx.New(b)
 	c := contract.New(b, t)
 	q := query.New(b)
 	u := utxo.New(b, t)
 	acc := wallet.New(b)
+	if r, ok := b.(reauthSetter); ok {
+		r.SetReauth(func(ctx context.Context, b modus.Base) error {
+			return auth.New(b).AutoLoginContext(ctx)
+		})
+	}
 	return &client{Authentication: a, Base: b, Contract: c, Transaction: t, Query: q, Utxo: u, Wallet: acc}
 }
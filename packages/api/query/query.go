@@ -1,6 +1,7 @@
 package query
 
 import (
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -8,6 +9,7 @@ import (
 	"github.com/IBAX-io/go-ibax-sdk/packages/modus"
 	"github.com/IBAX-io/go-ibax-sdk/packages/request"
 	"github.com/IBAX-io/go-ibax-sdk/packages/response"
+	"github.com/IBAX-io/go-ibax-sdk/packages/tracing"
 	"net/url"
 	"strconv"
 )
@@ -21,8 +23,12 @@ func New(b modus.Base) modus.Query {
 }
 
 func (q *query) GetKeyInfo(account string) (*response.KeyInfoResult, error) {
+	return q.GetKeyInfoContext(context.Background(), account)
+}
+
+func (q *query) GetKeyInfoContext(ctx context.Context, account string) (*response.KeyInfoResult, error) {
 	var ret response.KeyInfoResult
-	err := q.SendGet(`keyinfo/`+account, nil, &ret)
+	err := q.SendGetContext(ctx, `keyinfo/`+account, nil, &ret)
 	if err != nil {
 		return &ret, err
 	}
@@ -30,9 +36,13 @@ func (q *query) GetKeyInfo(account string) (*response.KeyInfoResult, error) {
 }
 
 func (q *query) EcosystemName(ecosystem int64) (*response.EcosystemNameResult, error) {
+	return q.EcosystemNameContext(context.Background(), ecosystem)
+}
+
+func (q *query) EcosystemNameContext(ctx context.Context, ecosystem int64) (*response.EcosystemNameResult, error) {
 	var result response.EcosystemNameResult
 	reqUrl := fmt.Sprintf("ecosystemname?id=%d", ecosystem)
-	err := q.SendGet(reqUrl, nil, &result)
+	err := q.SendGetContext(ctx, reqUrl, nil, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -43,9 +53,13 @@ func (q *query) EcosystemName(ecosystem int64) (*response.EcosystemNameResult, e
 // names Use‘,’ split multiple query parameters
 // names if is zero value: get all
 func (q *query) SystemParams(names string, params ...int) (*response.ParamsResult, error) {
+	return q.SystemParamsContext(context.Background(), names, params...)
+}
+
+func (q *query) SystemParamsContext(ctx context.Context, names string, params ...int) (*response.ParamsResult, error) {
 	var result response.ParamsResult
 	systemParamsUrl := fmt.Sprintf("systemparams?names=%s", names)
-	err := q.SendGet(systemParamsUrl, nil, &result)
+	err := q.SendGetContext(ctx, systemParamsUrl, nil, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -53,9 +67,13 @@ func (q *query) SystemParams(names string, params ...int) (*response.ParamsResul
 }
 
 func (q *query) EcosystemParam(ecosystem int64, name string) (*response.ParamResult, error) {
+	return q.EcosystemParamContext(context.Background(), ecosystem, name)
+}
+
+func (q *query) EcosystemParamContext(ctx context.Context, ecosystem int64, name string) (*response.ParamResult, error) {
 	var result response.ParamResult
 	reqUrl := fmt.Sprintf("ecosystemparam/%s?ecosystem=%d", name, ecosystem)
-	err := q.SendGet(reqUrl, nil, &result)
+	err := q.SendGetContext(ctx, reqUrl, nil, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -66,9 +84,13 @@ func (q *query) EcosystemParam(ecosystem int64, name string) (*response.ParamRes
 // names Use‘,’ split multiple query parameters
 // names if is zero value: get all
 func (q *query) EcosystemParams(ecosystem int64, names string, params ...int) (*response.ParamsResult, error) {
+	return q.EcosystemParamsContext(context.Background(), ecosystem, names, params...)
+}
+
+func (q *query) EcosystemParamsContext(ctx context.Context, ecosystem int64, names string, params ...int) (*response.ParamsResult, error) {
 	var result response.ParamsResult
 	ecosystemParamsUrl := fmt.Sprintf("ecosystemparams?ecosystem=%d&names=%s", ecosystem, names)
-	err := q.SendGet(ecosystemParamsUrl, nil, &result)
+	err := q.SendGetContext(ctx, ecosystemParamsUrl, nil, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -76,9 +98,13 @@ func (q *query) EcosystemParams(ecosystem int64, names string, params ...int) (*
 }
 
 func (q *query) GetHistory(table string, id uint64) (*response.HistoryResult, error) {
+	return q.GetHistoryContext(context.Background(), table, id)
+}
+
+func (q *query) GetHistoryContext(ctx context.Context, table string, id uint64) (*response.HistoryResult, error) {
 	var result response.HistoryResult
 	historyUrl := fmt.Sprintf("history/%s/%d", table, id)
-	err := q.SendGet(historyUrl, nil, &result)
+	err := q.SendGetContext(ctx, historyUrl, nil, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -86,9 +112,13 @@ func (q *query) GetHistory(table string, id uint64) (*response.HistoryResult, er
 }
 
 func (q *query) GetBalance(wallet string) (*response.TokenBalanceResult, error) {
+	return q.GetBalanceContext(context.Background(), wallet)
+}
+
+func (q *query) GetBalanceContext(ctx context.Context, wallet string) (*response.TokenBalanceResult, error) {
 	var result response.TokenBalanceResult
 	reqUrl := fmt.Sprintf("balance/%s", wallet)
-	err := q.SendGet(reqUrl, nil, &result)
+	err := q.SendGetContext(ctx, reqUrl, nil, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -100,9 +130,13 @@ type maxBlockResult struct {
 }
 
 func (q *query) GetMaxBlockID() (int64, error) {
+	return q.GetMaxBlockIDContext(context.Background())
+}
+
+func (q *query) GetMaxBlockIDContext(ctx context.Context) (int64, error) {
 	var result maxBlockResult
 	maxBlockIdUrl := fmt.Sprintf("maxblockid")
-	err := q.SendGet(maxBlockIdUrl, nil, &result)
+	err := q.SendGetContext(ctx, maxBlockIdUrl, nil, &result)
 	if err != nil {
 		return 0, err
 	}
@@ -110,10 +144,14 @@ func (q *query) GetMaxBlockID() (int64, error) {
 }
 
 func (q *query) GetBlockInfo(id int64) (*response.BlockInfoResult, error) {
+	return q.GetBlockInfoContext(context.Background(), id)
+}
+
+func (q *query) GetBlockInfoContext(ctx context.Context, id int64) (*response.BlockInfoResult, error) {
 	var rlt response.BlockInfoHexResult
 	var rets response.BlockInfoResult
 	blockInfoUrl := fmt.Sprintf("block/%d", id)
-	err := q.SendGet(blockInfoUrl, nil, &rlt)
+	err := q.SendGetContext(ctx, blockInfoUrl, nil, &rlt)
 	if err != nil {
 		return &rets, err
 	}
@@ -128,9 +166,13 @@ func (q *query) GetBlockInfo(id int64) (*response.BlockInfoResult, error) {
 }
 
 func (q *query) Balance(wallet string, ecosystem int64) (*response.TokenBalanceResult, error) {
+	return q.BalanceContext(context.Background(), wallet, ecosystem)
+}
+
+func (q *query) BalanceContext(ctx context.Context, wallet string, ecosystem int64) (*response.TokenBalanceResult, error) {
 	var result response.TokenBalanceResult
 	balanceUrl := fmt.Sprintf("balance/%s?ecosystem=%d", wallet, ecosystem)
-	err := q.SendGet(balanceUrl, nil, &result)
+	err := q.SendGetContext(ctx, balanceUrl, nil, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -138,9 +180,13 @@ func (q *query) Balance(wallet string, ecosystem int64) (*response.TokenBalanceR
 }
 
 func (q *query) AppParams(appid int64, names string, ecosystem int64, params ...int) (*response.AppParamsResult, error) {
+	return q.AppParamsContext(context.Background(), appid, names, ecosystem, params...)
+}
+
+func (q *query) AppParamsContext(ctx context.Context, appid int64, names string, ecosystem int64, params ...int) (*response.AppParamsResult, error) {
 	var rets response.AppParamsResult
 	appParamsUrl := fmt.Sprintf("appparams/%d?ecosystem=%d&names=%s", appid, ecosystem, names)
-	err := q.SendGet(appParamsUrl, nil, &rets)
+	err := q.SendGetContext(ctx, appParamsUrl, nil, &rets)
 	if err != nil {
 		return &rets, err
 	}
@@ -148,9 +194,13 @@ func (q *query) AppParams(appid int64, names string, ecosystem int64, params ...
 }
 
 func (q *query) AppParam(appid int64, name string, ecosystem int64) (*response.ParamResult, error) {
+	return q.AppParamContext(context.Background(), appid, name, ecosystem)
+}
+
+func (q *query) AppParamContext(ctx context.Context, appid int64, name string, ecosystem int64) (*response.ParamResult, error) {
 	var rets response.ParamResult
 	appParamUrl := fmt.Sprintf("appparam/%d/%s?ecosystem=%d", appid, name, ecosystem)
-	err := q.SendGet(appParamUrl, nil, &rets)
+	err := q.SendGetContext(ctx, appParamUrl, nil, &rets)
 	if err != nil {
 		return &rets, err
 	}
@@ -159,12 +209,16 @@ func (q *query) AppParam(appid int64, name string, ecosystem int64) (*response.P
 
 // GetRow return where table id = id AND Login ecosystem = ecosystem, rowsName optional
 func (q *query) GetRow(tableName string, id int64, columns string, whereColumn string) (*response.RowResult, error) {
+	return q.GetRowContext(context.Background(), tableName, id, columns, whereColumn)
+}
+
+func (q *query) GetRowContext(ctx context.Context, tableName string, id int64, columns string, whereColumn string) (*response.RowResult, error) {
 	var result response.RowResult
 	getRowUrl := fmt.Sprintf("row/%s/%d", tableName, id)
 	if columns != "" {
 		getRowUrl += fmt.Sprintf("?columns=%s", columns)
 	}
-	err := q.SendGet(getRowUrl, nil, &result)
+	err := q.SendGetContext(ctx, getRowUrl, nil, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -173,12 +227,16 @@ func (q *query) GetRow(tableName string, id int64, columns string, whereColumn s
 
 // GetRowExtend return where columns = value AND Login ecosystem = ecosystem, rowsName optional
 func (q *query) GetRowExtend(tableName, columns string, value string, rowsName string) (*response.RowResult, error) {
+	return q.GetRowExtendContext(context.Background(), tableName, columns, value, rowsName)
+}
+
+func (q *query) GetRowExtendContext(ctx context.Context, tableName, columns string, value string, rowsName string) (*response.RowResult, error) {
 	var result response.RowResult
 	getRowUrl := fmt.Sprintf("row/%s/%s/%s", tableName, columns, value)
 	if rowsName != "" {
 		getRowUrl += fmt.Sprintf("?columns=%s", rowsName)
 	}
-	err := q.SendGet(getRowUrl, nil, &result)
+	err := q.SendGetContext(ctx, getRowUrl, nil, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -187,9 +245,13 @@ func (q *query) GetRowExtend(tableName, columns string, value string, rowsName s
 
 // GetPageRow return page name = name and ecosystem id = login ecosystem id
 func (q *query) GetPageRow(name string) (*response.PageResult, error) {
+	return q.GetPageRowContext(context.Background(), name)
+}
+
+func (q *query) GetPageRowContext(ctx context.Context, name string) (*response.PageResult, error) {
 	var result response.PageResult
 	getRowUrl := fmt.Sprintf("interface/page/%s", name)
-	err := q.SendGet(getRowUrl, nil, &result)
+	err := q.SendGetContext(ctx, getRowUrl, nil, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -198,9 +260,13 @@ func (q *query) GetPageRow(name string) (*response.PageResult, error) {
 
 // GetMenuRow return menu name = name and ecosystem id = login ecosystem id
 func (q *query) GetMenuRow(name string) (*response.MenuResult, error) {
+	return q.GetMenuRowContext(context.Background(), name)
+}
+
+func (q *query) GetMenuRowContext(ctx context.Context, name string) (*response.MenuResult, error) {
 	var result response.MenuResult
 	getMenuUrl := fmt.Sprintf("interface/menu/%s", name)
-	err := q.SendGet(getMenuUrl, nil, &result)
+	err := q.SendGetContext(ctx, getMenuUrl, nil, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -209,9 +275,13 @@ func (q *query) GetMenuRow(name string) (*response.MenuResult, error) {
 
 // GetSnippetRow return snippet name = name and ecosystem id = login ecosystem id
 func (q *query) GetSnippetRow(name string) (*response.SnippetResult, error) {
+	return q.GetSnippetRowContext(context.Background(), name)
+}
+
+func (q *query) GetSnippetRowContext(ctx context.Context, name string) (*response.SnippetResult, error) {
 	var result response.SnippetResult
 	getMenuUrl := fmt.Sprintf("interface/snippet/%s", name)
-	err := q.SendGet(getMenuUrl, nil, &result)
+	err := q.SendGetContext(ctx, getMenuUrl, nil, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -220,9 +290,13 @@ func (q *query) GetSnippetRow(name string) (*response.SnippetResult, error) {
 
 // GetAppContent Get Obtain application-related information (including page, snippet, menu)
 func (q *query) GetAppContent(appId int64) (*response.AppContentResult, error) {
+	return q.GetAppContentContext(context.Background(), appId)
+}
+
+func (q *query) GetAppContentContext(ctx context.Context, appId int64) (*response.AppContentResult, error) {
 	var result response.AppContentResult
 	reqUrl := fmt.Sprintf("appcontent/%d", appId)
-	err := q.SendGet(reqUrl, nil, &result)
+	err := q.SendGetContext(ctx, reqUrl, nil, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -230,10 +304,14 @@ func (q *query) GetAppContent(appId int64) (*response.AppContentResult, error) {
 }
 
 func (q *query) BlocksTxInfo(blockId, count int64) (*map[int64][]response.BlockTxInfo, error) {
+	return q.BlocksTxInfoContext(context.Background(), blockId, count)
+}
+
+func (q *query) BlocksTxInfoContext(ctx context.Context, blockId, count int64) (*map[int64][]response.BlockTxInfo, error) {
 	var result map[int64][]response.BlockTxInfoHex
 	var rlt map[int64][]response.BlockTxInfo
 	reqUrl := fmt.Sprintf("blocks?count=%d&block_id=%d", count, blockId)
-	err := q.SendGet(reqUrl, nil, &result)
+	err := q.SendGetContext(ctx, reqUrl, nil, &result)
 	if err != nil {
 		return &rlt, err
 	}
@@ -254,9 +332,13 @@ func (q *query) BlocksTxInfo(blockId, count int64) (*map[int64][]response.BlockT
 }
 
 func (q *query) DetailedBlocks(blockId, count int64) (*map[int64]response.BlockDetailedInfo, error) {
+	return q.DetailedBlocksContext(context.Background(), blockId, count)
+}
+
+func (q *query) DetailedBlocksContext(ctx context.Context, blockId, count int64) (*map[int64]response.BlockDetailedInfo, error) {
 	var result map[int64]response.BlockDetailedInfo
 	reqUrl := fmt.Sprintf("detailed_blocks?count=%d&block_id=%d", count, blockId)
-	err := q.SendGet(reqUrl, nil, &result)
+	err := q.SendGetContext(ctx, reqUrl, nil, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -268,9 +350,13 @@ type countResult struct {
 }
 
 func (q *query) BlocksCount() (int64, error) {
+	return q.BlocksCountContext(context.Background())
+}
+
+func (q *query) BlocksCountContext(ctx context.Context) (int64, error) {
 	var result countResult
 	reqUrl := fmt.Sprintf("metrics/blocks")
-	err := q.SendGet(reqUrl, nil, &result)
+	err := q.SendGetContext(ctx, reqUrl, nil, &result)
 	if err != nil {
 		return 0, err
 	}
@@ -278,9 +364,13 @@ func (q *query) BlocksCount() (int64, error) {
 }
 
 func (q *query) TransactionsCount() (int64, error) {
+	return q.TransactionsCountContext(context.Background())
+}
+
+func (q *query) TransactionsCountContext(ctx context.Context) (int64, error) {
 	var result countResult
 	reqUrl := fmt.Sprintf("metrics/transactions")
-	err := q.SendGet(reqUrl, nil, &result)
+	err := q.SendGetContext(ctx, reqUrl, nil, &result)
 	if err != nil {
 		return 0, err
 	}
@@ -288,9 +378,13 @@ func (q *query) TransactionsCount() (int64, error) {
 }
 
 func (q *query) EcosystemCount() (int64, error) {
+	return q.EcosystemCountContext(context.Background())
+}
+
+func (q *query) EcosystemCountContext(ctx context.Context) (int64, error) {
 	var result countResult
 	reqUrl := fmt.Sprintf("metrics/ecosystems")
-	err := q.SendGet(reqUrl, nil, &result)
+	err := q.SendGetContext(ctx, reqUrl, nil, &result)
 	if err != nil {
 		return 0, err
 	}
@@ -298,9 +392,13 @@ func (q *query) EcosystemCount() (int64, error) {
 }
 
 func (q *query) KeysCount() (int64, error) {
+	return q.KeysCountContext(context.Background())
+}
+
+func (q *query) KeysCountContext(ctx context.Context) (int64, error) {
 	var result countResult
 	reqUrl := fmt.Sprintf("metrics/keys")
-	err := q.SendGet(reqUrl, nil, &result)
+	err := q.SendGetContext(ctx, reqUrl, nil, &result)
 	if err != nil {
 		return 0, err
 	}
@@ -308,9 +406,13 @@ func (q *query) KeysCount() (int64, error) {
 }
 
 func (q *query) HonorNodesCount() (int64, error) {
+	return q.HonorNodesCountContext(context.Background())
+}
+
+func (q *query) HonorNodesCountContext(ctx context.Context) (int64, error) {
 	var result countResult
 	reqUrl := fmt.Sprintf("metrics/honornodes")
-	err := q.SendGet(reqUrl, nil, &result)
+	err := q.SendGetContext(ctx, reqUrl, nil, &result)
 	if err != nil {
 		return 0, err
 	}
@@ -319,11 +421,15 @@ func (q *query) HonorNodesCount() (int64, error) {
 
 // DataVerify return request.FileType or string
 func (q *query) DataVerify(tableName string, id int64, column, hash, fileName string) (result request.FileType, err error) {
+	return q.DataVerifyContext(context.Background(), tableName, id, column, hash, fileName)
+}
+
+func (q *query) DataVerifyContext(ctx context.Context, tableName string, id int64, column, hash, fileName string) (result request.FileType, err error) {
 	reqUrl := fmt.Sprintf("data/%s/%d/%s/%s", tableName, id, column, hash)
 	if fileName != "" {
 		result.Name = fileName
 	}
-	err = q.SendGet(reqUrl, nil, &result)
+	err = q.SendGetContext(ctx, reqUrl, nil, &result)
 	if err != nil {
 		return request.FileType{}, err
 	}
@@ -332,11 +438,15 @@ func (q *query) DataVerify(tableName string, id int64, column, hash, fileName st
 
 // BinaryVerify return request.FileType or string
 func (q *query) BinaryVerify(id int64, hash string, fileName string) (result request.FileType, err error) {
+	return q.BinaryVerifyContext(context.Background(), id, hash, fileName)
+}
+
+func (q *query) BinaryVerifyContext(ctx context.Context, id int64, hash string, fileName string) (result request.FileType, err error) {
 	reqUrl := fmt.Sprintf("data/%d/data/%s", id, hash)
 	if fileName != "" {
 		result.Name = fileName
 	}
-	err = q.SendGet(reqUrl, nil, &result)
+	err = q.SendGetContext(ctx, reqUrl, nil, &result)
 	if err != nil {
 		return request.FileType{}, err
 	}
@@ -344,6 +454,10 @@ func (q *query) BinaryVerify(id int64, hash string, fileName string) (result req
 }
 
 func (q *query) GetAvatar(account string, ecosystem int64, fileName string) (result request.FileType, err error) {
+	return q.GetAvatarContext(context.Background(), account, ecosystem, fileName)
+}
+
+func (q *query) GetAvatarContext(ctx context.Context, account string, ecosystem int64, fileName string) (result request.FileType, err error) {
 	reqUrl := fmt.Sprintf("avatar/%d/%s", ecosystem, account)
 	var v *request.FileType
 	if fileName != "" {
@@ -351,7 +465,7 @@ func (q *query) GetAvatar(account string, ecosystem int64, fileName string) (res
 	} else {
 		return result, errors.New("filename can't not be empty")
 	}
-	err = q.SendGet(reqUrl, nil, v)
+	err = q.SendGetContext(ctx, reqUrl, nil, v)
 	if err != nil {
 		return result, err
 	}
@@ -359,10 +473,41 @@ func (q *query) GetAvatar(account string, ecosystem int64, fileName string) (res
 	return result, nil
 }
 
+func (q *query) DataVerifyTo(stream *response.FileStream, tableName string, id int64, column, hash string) error {
+	return q.DataVerifyToContext(context.Background(), stream, tableName, id, column, hash)
+}
+
+func (q *query) DataVerifyToContext(ctx context.Context, stream *response.FileStream, tableName string, id int64, column, hash string) error {
+	reqUrl := fmt.Sprintf("data/%s/%d/%s/%s", tableName, id, column, hash)
+	return q.SendGetContext(ctx, reqUrl, nil, stream)
+}
+
+func (q *query) BinaryVerifyTo(stream *response.FileStream, id int64, hash string) error {
+	return q.BinaryVerifyToContext(context.Background(), stream, id, hash)
+}
+
+func (q *query) BinaryVerifyToContext(ctx context.Context, stream *response.FileStream, id int64, hash string) error {
+	reqUrl := fmt.Sprintf("data/%d/data/%s", id, hash)
+	return q.SendGetContext(ctx, reqUrl, nil, stream)
+}
+
+func (q *query) GetAvatarTo(stream *response.FileStream, account string, ecosystem int64) error {
+	return q.GetAvatarToContext(context.Background(), stream, account, ecosystem)
+}
+
+func (q *query) GetAvatarToContext(ctx context.Context, stream *response.FileStream, account string, ecosystem int64) error {
+	reqUrl := fmt.Sprintf("avatar/%d/%s", ecosystem, account)
+	return q.SendGetContext(ctx, reqUrl, nil, stream)
+}
+
 func (q *query) GetTableCount(offset, limit int) (*response.TablesResult, error) {
+	return q.GetTableCountContext(context.Background(), offset, limit)
+}
+
+func (q *query) GetTableCountContext(ctx context.Context, offset, limit int) (*response.TablesResult, error) {
 	var v response.TablesResult
 	reqUrl := fmt.Sprintf("tables?offset=%d&limit=%d", offset, limit)
-	err := q.SendGet(reqUrl, nil, &v)
+	err := q.SendGetContext(ctx, reqUrl, nil, &v)
 	if err != nil {
 		return &v, err
 	}
@@ -370,9 +515,13 @@ func (q *query) GetTableCount(offset, limit int) (*response.TablesResult, error)
 }
 
 func (q *query) GetTable(tableName string) (*response.TableResult, error) {
+	return q.GetTableContext(context.Background(), tableName)
+}
+
+func (q *query) GetTableContext(ctx context.Context, tableName string) (*response.TableResult, error) {
 	var result response.TableResult
 	reqUrl := fmt.Sprintf("table/%s", tableName)
-	err := q.SendGet(reqUrl, nil, &result)
+	err := q.SendGetContext(ctx, reqUrl, nil, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -380,9 +529,15 @@ func (q *query) GetTable(tableName string) (*response.TableResult, error) {
 }
 
 func (q *query) GetList(params request.GetList) (*response.ListResult, error) {
+	return q.GetListContext(context.Background(), params)
+}
+
+func (q *query) GetListContext(ctx context.Context, params request.GetList) (_ *response.ListResult, err error) {
+	ctx, span := tracing.Of(q.Base).Start(ctx, "GetList", "table", params.Name)
+	defer tracing.End(span, &err)
 	var result response.ListResult
 	getListUrl := fmt.Sprintf("list/%s?limit=%d&offset=%d&columns=%s", params.Name, params.Limit, params.Offset, params.Columns)
-	err := q.SendGet(getListUrl, nil, &result)
+	err = q.SendGetContext(ctx, getListUrl, nil, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -390,12 +545,16 @@ func (q *query) GetList(params request.GetList) (*response.ListResult, error) {
 }
 
 func (q *query) GetSections(language string, offset, limit int) (*response.ListResult, error) {
+	return q.GetSectionsContext(context.Background(), language, offset, limit)
+}
+
+func (q *query) GetSectionsContext(ctx context.Context, language string, offset, limit int) (*response.ListResult, error) {
 	var result response.ListResult
 	reqUrl := fmt.Sprintf("sections?offset=%d&limit=%d", offset, limit)
 	if language != "" {
 		reqUrl += fmt.Sprintf("&lang=%s", language)
 	}
-	err := q.SendGet(reqUrl, nil, &result)
+	err := q.SendGetContext(ctx, reqUrl, nil, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -403,9 +562,13 @@ func (q *query) GetSections(language string, offset, limit int) (*response.ListR
 }
 
 func (q *query) GetIBAXConfig(option string) (*string, error) {
+	return q.GetIBAXConfigContext(context.Background(), option)
+}
+
+func (q *query) GetIBAXConfigContext(ctx context.Context, option string) (*string, error) {
 	var result string
 	reqUrl := "config/" + option
-	err := q.SendGet(reqUrl, nil, &result)
+	err := q.SendGetContext(ctx, reqUrl, nil, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -413,9 +576,13 @@ func (q *query) GetIBAXConfig(option string) (*string, error) {
 }
 
 func (q *query) GetVersion() (*string, error) {
+	return q.GetVersionContext(context.Background())
+}
+
+func (q *query) GetVersionContext(ctx context.Context) (*string, error) {
 	var result string
 	reqUrl := "version"
-	err := q.SendGet(reqUrl, nil, &result)
+	err := q.SendGetContext(ctx, reqUrl, nil, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -423,6 +590,10 @@ func (q *query) GetVersion() (*string, error) {
 }
 
 func (q *query) GetListWhere(params request.GetList) (*response.ListResult, error) {
+	return q.GetListWhereContext(context.Background(), params)
+}
+
+func (q *query) GetListWhereContext(ctx context.Context, params request.GetList) (*response.ListResult, error) {
 	var result response.ListResult
 	reqUrl := fmt.Sprintf("listWhere/%s", params.Name)
 
@@ -438,7 +609,7 @@ func (q *query) GetListWhere(params request.GetList) (*response.ListResult, erro
 		form.Set("columns", params.Columns)
 	}
 	if params.Offset < 0 || params.Limit <= 0 {
-		return &result, errors.New("params invalid")
+		return &result, response.ErrInvalidParams
 	}
 	form.Set("offset", strconv.Itoa(params.Offset))
 	if params.Where != nil {
@@ -449,7 +620,7 @@ func (q *query) GetListWhere(params request.GetList) (*response.ListResult, erro
 		form.Set("where", string(data))
 	}
 
-	err := q.SendPost(reqUrl, form, &result)
+	err := q.SendPostContext(ctx, reqUrl, form, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -457,6 +628,10 @@ func (q *query) GetListWhere(params request.GetList) (*response.ListResult, erro
 }
 
 func (q *query) GetNodeListWhere(params request.GetList) (*response.ListResult, error) {
+	return q.GetNodeListWhereContext(context.Background(), params)
+}
+
+func (q *query) GetNodeListWhereContext(ctx context.Context, params request.GetList) (*response.ListResult, error) {
 	var result response.ListResult
 	reqUrl := fmt.Sprintf("nodelistWhere/%s", params.Name)
 
@@ -472,7 +647,7 @@ func (q *query) GetNodeListWhere(params request.GetList) (*response.ListResult,
 		form.Set("columns", params.Columns)
 	}
 	if params.Offset < 0 || params.Limit <= 0 {
-		return &result, errors.New("params invalid")
+		return &result, response.ErrInvalidParams
 	}
 	form.Set("limit", strconv.Itoa(params.Limit))
 	form.Set("offset", strconv.Itoa(params.Offset))
@@ -484,7 +659,7 @@ func (q *query) GetNodeListWhere(params request.GetList) (*response.ListResult,
 		form.Set("where", string(data))
 	}
 
-	err := q.SendPost(reqUrl, form, &result)
+	err := q.SendPostContext(ctx, reqUrl, form, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -492,17 +667,102 @@ func (q *query) GetNodeListWhere(params request.GetList) (*response.ListResult,
 }
 
 func (q *query) BlockTxCount(blockIdOrHash any) (int64, error) {
-	return 0, response.NotSupportError
+	return q.BlockTxCountContext(context.Background(), blockIdOrHash)
+}
+
+func (q *query) BlockTxCountContext(ctx context.Context, blockIdOrHash any) (int64, error) {
+	if err := blockIdOrBlockHashValidate(blockIdOrHash); err != nil {
+		return 0, err
+	}
+	if by, ok := blockIdOrHash.(request.BlockIdOrHash); ok && by.Id > 0 {
+		info, err := q.GetBlockInfoContext(ctx, by.Id)
+		if err != nil {
+			return 0, err
+		}
+		return int64(info.Tx), nil
+	}
+	var result int64
+	err := q.call(ctx, "getTransactionCount", &result, blockIdOrHash)
+	return result, err
 }
 
 func (q *query) DetailedBlock(blockIdOrBlockHash any) (*response.BlockDetailedInfo, error) {
-	return nil, response.NotSupportError
+	return q.DetailedBlockContext(context.Background(), blockIdOrBlockHash)
+}
+
+// DetailedBlockContext gets a block by id from detailed_blocks, the RESTful api does not find a block by hash
+func (q *query) DetailedBlockContext(ctx context.Context, blockIdOrBlockHash any) (*response.BlockDetailedInfo, error) {
+	if err := blockIdOrBlockHashValidate(blockIdOrBlockHash); err != nil {
+		return nil, err
+	}
+	var result response.BlockDetailedInfo
+	if by, ok := blockIdOrBlockHash.(request.BlockIdOrHash); ok && by.Id > 0 {
+		blocks, err := q.DetailedBlocksContext(ctx, by.Id, 1)
+		if err != nil {
+			return &result, err
+		}
+		block, ok := (*blocks)[by.Id]
+		if !ok {
+			return &result, fmt.Errorf("block %d %w", by.Id, response.ErrNotFound)
+		}
+		return &block, nil
+	}
+	err := q.call(ctx, "detailedBlock", &result, blockIdOrBlockHash)
+	return &result, err
 }
 
 func (q *query) EcosystemInfo(ecosystem int64) (*response.EcosystemInfo, error) {
-	return nil, response.NotSupportError
+	return q.EcosystemInfoContext(context.Background(), ecosystem)
+}
+
+func (q *query) EcosystemInfoContext(ctx context.Context, ecosystem int64) (*response.EcosystemInfo, error) {
+	var result response.EcosystemInfo
+	err := q.call(ctx, "ecosystemInfo", &result, ecosystem)
+	return &result, err
 }
 
 func (q *query) GetMemberInfo(account string, ecosystem int64) (*response.MemberInfo, error) {
-	return nil, response.NotSupportError
+	return q.GetMemberInfoContext(context.Background(), account, ecosystem)
+}
+
+func (q *query) GetMemberInfoContext(ctx context.Context, account string, ecosystem int64) (*response.MemberInfo, error) {
+	var result response.MemberInfo
+	reqUrl := fmt.Sprintf("member/%d/%s", ecosystem, account)
+	err := q.SendGetContext(ctx, reqUrl, nil, &result)
+	if err != nil {
+		return &result, err
+	}
+	return &result, nil
+}
+
+// call sends a JSON-RPC method of the ibax namespace, for the queries the RESTful api does not serve
+func (q *query) call(ctx context.Context, method string, result any, params ...any) error {
+	req, err := q.NewMessage(request.RequestParams{
+		Namespace: request.NamespaceIBAX,
+		Name:      method,
+		Params:    params,
+	})
+	if err != nil {
+		return err
+	}
+	return q.GETContext(ctx, req, result)
+}
+
+func blockIdOrBlockHashValidate(req any) error {
+	switch v := req.(type) {
+	case string:
+		if v == "" {
+			return errors.New("params can't not be empty")
+		}
+	case request.BlockIdOrHash:
+		if v.Id <= 0 && v.Hash == "" {
+			return errors.New("params can't not be empty")
+		}
+		if v.Id > 0 && v.Hash != "" {
+			return errors.New("block id or block hash must be only choose one")
+		}
+	default:
+		return fmt.Errorf("params is not BlockIdOrHash type")
+	}
+	return nil
 }
@@ -1,15 +1,18 @@
 package contract
 
 import (
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"github.com/IBAX-io/go-ibax-sdk/packages/logger"
+	"github.com/IBAX-io/go-ibax-sdk/packages/metrics"
 	"github.com/IBAX-io/go-ibax-sdk/packages/modus"
 	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/transaction"
 	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/types"
 	"github.com/IBAX-io/go-ibax-sdk/packages/request"
 	"github.com/IBAX-io/go-ibax-sdk/packages/response"
-	"github.com/IBAX-io/go-ibax/packages/common/crypto"
+	"github.com/IBAX-io/go-ibax-sdk/packages/tracing"
 	"github.com/shopspring/decimal"
 	"strconv"
 	"time"
@@ -44,9 +47,13 @@ func New(b modus.Base, tx modus.Transaction) modus.Contract {
 // GetContracts
 // Get Login ecosystem contracts
 func (c *contract) GetContracts(limit, offset int64) (*response.ListResult, error) {
+	return c.GetContractsContext(context.Background(), limit, offset)
+}
+
+func (c *contract) GetContractsContext(ctx context.Context, limit, offset int64) (*response.ListResult, error) {
 	var result response.ListResult
 	getContractsUrl := fmt.Sprintf("contracts?limit=%d&offset=%d", limit, offset)
-	err := c.SendGet(getContractsUrl, nil, &result)
+	err := c.SendGetContext(ctx, getContractsUrl, nil, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -56,9 +63,13 @@ func (c *contract) GetContracts(limit, offset int64) (*response.ListResult, erro
 // GetContract
 // Get Login ecosystem contract by contract name
 func (c *contract) GetContract(contractName string) (*response.GetContractResult, error) {
+	return c.GetContractContext(context.Background(), contractName)
+}
+
+func (c *contract) GetContractContext(ctx context.Context, contractName string) (*response.GetContractResult, error) {
 	var result response.GetContractResult
 	getContractUrl := fmt.Sprintf("contract/%s", contractName)
-	err := c.SendGet(getContractUrl, nil, &result)
+	err := c.SendGetContext(ctx, getContractUrl, nil, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -66,8 +77,12 @@ func (c *contract) GetContract(contractName string) (*response.GetContractResult
 }
 
 func (c *contract) PrepareContractTx(contractName string, form modus.Getter) (params map[string]any, contractId uint32, err error) {
+	return c.PrepareContractTxContext(context.Background(), contractName, form)
+}
+
+func (c *contract) PrepareContractTxContext(ctx context.Context, contractName string, form modus.Getter) (params map[string]any, contractId uint32, err error) {
 	var contract getContractInfo
-	if err = c.SendGet("contract/"+contractName, nil, &contract); err != nil {
+	if err = c.SendGetContext(ctx, "contract/"+contractName, nil, &contract); err != nil {
 		return
 	}
 
@@ -123,29 +138,35 @@ func (c *contract) NewContractTransaction(contractId uint32, params map[string]a
 		}
 	}
 	cnf := c.Base.GetConfig()
-	var privateKey, publicKey []byte
-	if privateKey, err = hex.DecodeString(cnf.PrivateKey); err != nil {
+	var publicKey []byte
+	s := c.Signer()
+	if publicKey, err = s.PublicKey(context.Background()); err != nil {
 		return
 	}
-	if publicKey, err = crypto.PrivateToPublic(privateKey); err != nil {
-		return
-	}
-	data, hash, err = transaction.NewTransactionInProc(types.SmartTransaction{
+	p := c.Crypto()
+	data, hash, err = transaction.NewSignedTransactionWithLogger(context.Background(), p, logger.Of(c.Base), types.SmartTransaction{
 		Header: &types.Header{
 			ID:          contractId,
 			Time:        time.Now().Unix(),
 			EcosystemID: cnf.Ecosystem,
-			KeyID:       crypto.Address(publicKey),
+			KeyID:       p.Address(publicKey),
 			NetworkID:   cnf.NetworkId,
 		},
 		Params:   params,
 		Expedite: expedite,
-	}, privateKey)
+	}, s)
 
 	return
 }
 
 func (c *contract) AutoCallContract(contractName string, form modus.Getter, expedite string) (*response.TxStatusResult, error) {
+	return c.AutoCallContractContext(context.Background(), contractName, form, expedite)
+}
+
+func (c *contract) AutoCallContractContext(ctx context.Context, contractName string, form modus.Getter, expedite string) (result *response.TxStatusResult, err error) {
+	tracer := tracing.Of(c.Base)
+	ctx, span := tracer.Start(ctx, "AutoCallContract", "contract", contractName)
+	defer tracing.End(span, &err)
 	var rets = response.TxStatusResult{}
 	if expedite != "" {
 		//Uniform use min uint
@@ -159,45 +180,48 @@ func (c *contract) AutoCallContract(contractName string, form modus.Getter, expe
 			return &rets, err
 		}
 	}
-	params, contractId, err := c.PrepareContractTx(contractName, form)
+	infoCtx, info := tracer.Start(ctx, "GetContractInfo", "contract", contractName)
+	params, contractId, err := c.PrepareContractTxContext(infoCtx, contractName, form)
+	tracing.End(info, &err)
 	if err != nil {
 		return &rets, err
 	}
 
 	cnf := c.Base.GetConfig()
-	var privateKey []byte
-	if privateKey, err = hex.DecodeString(cnf.PrivateKey); err != nil {
-		return &rets, err
-	}
-
 	arrData := make(map[string][]byte)
-	data, txhash, err := transaction.NewTransactionInProc(types.SmartTransaction{
+	signCtx, sign := tracer.Start(ctx, "SignTx")
+	data, txhash, err := transaction.NewSignedTransactionWithLogger(signCtx, c.Crypto(), logger.Of(c.Base), types.SmartTransaction{
 		Header: &types.Header{
 			ID:          contractId,
 			Time:        time.Now().Unix(),
 			EcosystemID: cnf.Ecosystem,
-			KeyID:       crypto.Address(cnf.PublicKey),
+			KeyID:       cnf.KeyId,
 			NetworkID:   cnf.NetworkId,
 		},
 		Params:   params,
 		Expedite: expedite,
-	}, privateKey)
+	}, c.Signer())
+	tracing.End(sign, &err)
 	if err != nil {
 		return &rets, err
 	}
 	arrData[fmt.Sprintf("%x", txhash)] = data
+	span.SetAttributes("hash", hex.EncodeToString(txhash))
 
 	ret := &response.SendTxResult{}
-	err = c.SendMultipart("sendTx", arrData, &ret)
+	sendCtx, send := tracer.Start(ctx, "SendTx")
+	err = c.SendMultipartContext(sendCtx, "sendTx", arrData, &ret)
+	tracing.End(send, &err)
 	if err != nil {
 		return &rets, err
 	}
+	metrics.Of(c.Base).AddTxSent(len(arrData))
 
 	if form != nil && len(form.Get("nowait")) > 0 {
 		return &rets, nil
 	}
 
-	rets, err = c.TxStatus(hex.EncodeToString(txhash), 10, time.Second*1)
+	rets, err = c.TxStatusContext(ctx, hex.EncodeToString(txhash), 10, time.Second*1)
 	if err != nil {
 		return &rets, err
 	}
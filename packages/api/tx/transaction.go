@@ -1,12 +1,16 @@
 package tx
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/IBAX-io/go-ibax-sdk/packages/metrics"
 	"github.com/IBAX-io/go-ibax-sdk/packages/modus"
 	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/converter"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/utils"
 	"github.com/IBAX-io/go-ibax-sdk/packages/response"
+	"github.com/IBAX-io/go-ibax-sdk/packages/tracing"
 	"net/url"
 	"strings"
 	"time"
@@ -47,14 +51,24 @@ func New(b modus.Base) modus.Transaction {
 // hash: transaction hash
 // interval: After the transaction is sent, the time interval for each query of the transaction status
 func (c *tx) TxStatus(hash string, frequency int, interval time.Duration) (response.TxStatusResult, error) {
-	return c.waitTx(hash, frequency, interval)
+	return c.TxStatusContext(context.Background(), hash, frequency, interval)
 }
 
-func (c *tx) waitTx(hash string, frequency int, interval time.Duration) (rets response.TxStatusResult, err error) {
+func (c *tx) TxStatusContext(ctx context.Context, hash string, frequency int, interval time.Duration) (response.TxStatusResult, error) {
+	return c.waitTx(ctx, hash, frequency, interval)
+}
+
+func (c *tx) waitTx(ctx context.Context, hash string, frequency int, interval time.Duration) (rets response.TxStatusResult, err error) {
+	start := time.Now()
+	tracer := tracing.Of(c.base)
+	ctx, span := tracer.Start(ctx, "TxStatus", "hash", hash)
+	defer tracing.End(span, &err)
 	if interval.Milliseconds() < waitTxMinInterval.Milliseconds() {
 		interval = waitTxMinInterval
 	}
-	time.Sleep(interval)
+	if err = utils.Sleep(ctx, interval); err != nil {
+		return
+	}
 	data, err := json.Marshal(&txStatusRequest{
 		Hashes: []string{hash},
 	})
@@ -64,9 +78,11 @@ func (c *tx) waitTx(hash string, frequency int, interval time.Duration) (rets re
 	rets.Hash = hash
 	for i := 1; i < frequency; i++ {
 		var multiRet multiTxStatusResult
-		err = c.base.SendPost(`txstatus`, &url.Values{
+		pollCtx, poll := tracer.Start(ctx, "TxStatusPoll", "attempt", i)
+		err = c.base.SendPostContext(pollCtx, `txstatus`, &url.Values{
 			"data": {string(data)},
 		}, &multiRet)
+		tracing.End(poll, &err)
 		if err != nil {
 			return
 		}
@@ -76,6 +92,8 @@ func (c *tx) waitTx(hash string, frequency int, interval time.Duration) (rets re
 		if len(ret.BlockID) > 0 {
 			rets.BlockId = converter.StrToInt64(ret.BlockID)
 			rets.Penalty = ret.Penalty
+			metrics.Of(c.base).ObserveTxConfirmed(time.Since(start), ret.Penalty == 1)
+			span.SetAttributes("block_id", rets.BlockId, "penalty", ret.Penalty)
 			if ret.Penalty == 1 {
 				errText, err = json.Marshal(ret.Message)
 				if err != nil {
@@ -100,7 +118,9 @@ func (c *tx) waitTx(hash string, frequency int, interval time.Duration) (rets re
 			rets.Err = string(errText)
 			return
 		}
-		time.Sleep(interval)
+		if err = utils.Sleep(ctx, interval); err != nil {
+			return
+		}
 	}
 
 	return rets, nil
@@ -110,10 +130,17 @@ func (c *tx) waitTx(hash string, frequency int, interval time.Duration) (rets re
 // hashList: multiple transaction hash
 // interval: After the transaction is sent, the time interval for each query of the transaction status
 func (c *tx) TxsStatus(hashList []string, interval time.Duration) (map[string]response.TxStatusResult, error) {
+	return c.TxsStatusContext(context.Background(), hashList, interval)
+}
+
+func (c *tx) TxsStatusContext(ctx context.Context, hashList []string, interval time.Duration) (map[string]response.TxStatusResult, error) {
+	start := time.Now()
 	if interval.Milliseconds() < waitTxMinInterval.Milliseconds() {
 		interval = waitTxMinInterval
 	}
-	time.Sleep(interval)
+	if err := utils.Sleep(ctx, interval); err != nil {
+		return nil, err
+	}
 	data, err := json.Marshal(&txStatusRequest{
 		Hashes: hashList,
 	})
@@ -130,7 +157,7 @@ func (c *tx) TxsStatus(hashList []string, interval time.Duration) (map[string]re
 	// again: If the transaction status is not queried, try again
 again:
 	var multiRet multiTxStatusResult
-	err = c.base.SendPost("txstatus", &url.Values{
+	err = c.base.SendPostContext(ctx, "txstatus", &url.Values{
 		"data": {string(data)},
 	}, &multiRet)
 	if err != nil {
@@ -143,6 +170,7 @@ again:
 		if len(v.BlockID) > 0 {
 			result.BlockId = converter.StrToInt64(v.BlockID)
 			result.Penalty = v.Penalty
+			metrics.Of(c.base).ObserveTxConfirmed(time.Since(start), v.Penalty == 1)
 			if v.Penalty == 1 {
 				errtext, err = json.Marshal(v.Message)
 				if err != nil {
@@ -175,7 +203,9 @@ again:
 	}
 	if len(rets) == 0 && againNumber < 10 {
 		againNumber++
-		time.Sleep(interval)
+		if err = utils.Sleep(ctx, interval); err != nil {
+			return nil, err
+		}
 		goto again
 	}
 
@@ -183,23 +213,39 @@ again:
 }
 
 func (c *tx) SendTx(arrData map[string][]byte) (*map[string]string, error) {
+	return c.SendTxContext(context.Background(), arrData)
+}
+
+func (c *tx) SendTxContext(ctx context.Context, arrData map[string][]byte) (*map[string]string, error) {
 	ret := &response.SendTxResult{}
 	hashMap := map[string]string{}
-	err := c.base.SendMultipart("sendTx", arrData, &ret)
+	err := c.base.SendMultipartContext(ctx, "sendTx", arrData, &ret)
 	if err != nil {
 		return &hashMap, err
 	}
+	metrics.Of(c.base).AddTxSent(len(arrData))
 	hashMap = ret.Hashes
+	if len(hashMap) == 0 {
+		//the node had received the transactions before they were sent again
+		hashMap = make(map[string]string, len(arrData))
+		for key := range arrData {
+			hashMap[key] = key
+		}
+	}
 	return &hashMap, nil
 }
 
 func (c *tx) GetTxInfo(hash string, getContractInfo bool) (*response.TxInfoResult, error) {
+	return c.GetTxInfoContext(context.Background(), hash, getContractInfo)
+}
+
+func (c *tx) GetTxInfoContext(ctx context.Context, hash string, getContractInfo bool) (*response.TxInfoResult, error) {
 	var result response.TxInfoResult
 	reqUrl := fmt.Sprintf("txinfo/%s", hash)
 	if getContractInfo {
 		reqUrl += fmt.Sprintf("?contractinfo=1")
 	}
-	err := c.base.SendGet(reqUrl, nil, &result)
+	err := c.base.SendGetContext(ctx, reqUrl, nil, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -208,10 +254,14 @@ func (c *tx) GetTxInfo(hash string, getContractInfo bool) (*response.TxInfoResul
 }
 
 func (c *tx) GetTxInfoMulti(hashList []string, getContractInfo bool) (*response.MultiTxInfoResult, error) {
+	return c.GetTxInfoMultiContext(context.Background(), hashList, getContractInfo)
+}
+
+func (c *tx) GetTxInfoMultiContext(ctx context.Context, hashList []string, getContractInfo bool) (*response.MultiTxInfoResult, error) {
 	var result response.MultiTxInfoResult
 	reqUrl := fmt.Sprintf("txinfomultiple")
 	if len(hashList) == 0 {
-		return &result, errors.New("params invalid")
+		return &result, response.ErrInvalidParams
 	}
 	data := strings.Join(hashList, ",")
 	reqUrl += fmt.Sprintf("?data=%s", data)
@@ -219,7 +269,7 @@ func (c *tx) GetTxInfoMulti(hashList []string, getContractInfo bool) (*response.
 	if getContractInfo {
 		reqUrl += fmt.Sprintf("&contractinfo=1")
 	}
-	err := c.base.SendGet(reqUrl, nil, &result)
+	err := c.base.SendGetContext(ctx, reqUrl, nil, &result)
 	if err != nil {
 		return &result, err
 	}
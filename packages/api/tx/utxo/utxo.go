@@ -1,15 +1,18 @@
 package utxo
 
 import (
+	"context"
 	"encoding/hex"
-	"errors"
 	"fmt"
+	"github.com/IBAX-io/go-ibax-sdk/packages/logger"
+	"github.com/IBAX-io/go-ibax-sdk/packages/metrics"
 	"github.com/IBAX-io/go-ibax-sdk/packages/modus"
 	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/converter"
 	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/transaction"
 	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/types"
 	"github.com/IBAX-io/go-ibax-sdk/packages/request"
 	"github.com/IBAX-io/go-ibax-sdk/packages/response"
+	"github.com/IBAX-io/go-ibax-sdk/packages/tracing"
 	"github.com/shopspring/decimal"
 	"time"
 )
@@ -48,7 +51,7 @@ func (u *utxo) NewUtxoSmartTransaction(txType request.UtxoType, form modus.Gette
 	}
 	amount := form.Get("amount")
 	if len(amount) == 0 {
-		return &smartTx, errors.New("amount params invalid")
+		return &smartTx, fmt.Errorf("%w: amount", response.ErrInvalidParams)
 	}
 	err := u.AmountValidator(amount)
 	if err != nil {
@@ -59,7 +62,7 @@ func (u *utxo) NewUtxoSmartTransaction(txType request.UtxoType, form modus.Gette
 		recipient := form.Get("recipient")
 		comment := form.Get("comment")
 		if len(recipient) == 0 {
-			return &smartTx, errors.New("recipient params invalid")
+			return &smartTx, fmt.Errorf("%w: recipient", response.ErrInvalidParams)
 		}
 		toId := converter.StringToAddress(recipient)
 		if toId == 0 && recipient != request.BlackHoleAddr {
@@ -88,18 +91,19 @@ func (u *utxo) NewUtxoSmartTransaction(txType request.UtxoType, form modus.Gette
 }
 
 func (u *utxo) NewUtxoTransaction(smartTransaction types.SmartTransaction) (data, hash []byte, err error) {
-	var privateKey []byte
-	cnf := u.GetConfig()
-	if privateKey, err = hex.DecodeString(cnf.PrivateKey); err != nil {
-		return
-	}
-
-	data, hash, err = transaction.NewTransactionInProc(smartTransaction, privateKey)
+	data, hash, err = transaction.NewSignedTransactionWithLogger(context.Background(), u.Crypto(), logger.Of(u.Base), smartTransaction, u.Signer())
 
 	return
 }
 
 func (u *utxo) AutoCallUtxo(txType request.UtxoType, form modus.Getter, expedite string) (*response.TxStatusResult, error) {
+	return u.AutoCallUtxoContext(context.Background(), txType, form, expedite)
+}
+
+func (u *utxo) AutoCallUtxoContext(ctx context.Context, txType request.UtxoType, form modus.Getter, expedite string) (result *response.TxStatusResult, err error) {
+	tracer := tracing.Of(u.Base)
+	ctx, span := tracer.Start(ctx, "AutoCallUtxo", "type", txType)
+	defer tracing.End(span, &err)
 	var (
 		rets = response.TxStatusResult{}
 	)
@@ -108,31 +112,31 @@ func (u *utxo) AutoCallUtxo(txType request.UtxoType, form modus.Getter, expedite
 		return &rets, err
 	}
 
-	cnf := u.GetConfig()
-	var privateKey []byte
-	if privateKey, err = hex.DecodeString(cnf.PrivateKey); err != nil {
-		return &rets, err
-	}
-
 	arrData := make(map[string][]byte)
-	data, txhash, err := transaction.NewTransactionInProc(*smartTx, privateKey)
+	signCtx, sign := tracer.Start(ctx, "SignTx")
+	data, txhash, err := transaction.NewSignedTransactionWithLogger(signCtx, u.Crypto(), logger.Of(u.Base), *smartTx, u.Signer())
+	tracing.End(sign, &err)
 	if err != nil {
 		return &rets, err
 	}
 	arrData[fmt.Sprintf("%x", txhash)] = data
+	span.SetAttributes("hash", hex.EncodeToString(txhash))
 	//fmt.Println(fmt.Sprintf("%x", txhash))
 
 	ret := &response.SendTxResult{}
-	err = u.SendMultipart("sendTx", arrData, &ret)
+	sendCtx, send := tracer.Start(ctx, "SendTx")
+	err = u.SendMultipartContext(sendCtx, "sendTx", arrData, &ret)
+	tracing.End(send, &err)
 	if err != nil {
 		return &rets, err
 	}
+	metrics.Of(u.Base).AddTxSent(len(arrData))
 
 	if form != nil && len(form.Get("nowait")) > 0 {
 		return &rets, nil
 	}
 
-	rets, err = u.TxStatus(hex.EncodeToString(txhash), 10, time.Second*1)
+	rets, err = u.TxStatusContext(ctx, hex.EncodeToString(txhash), 10, time.Second*1)
 	if err != nil {
 		return &rets, err
 	}
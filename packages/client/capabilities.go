@@ -0,0 +1,167 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/IBAX-io/go-ibax-sdk/config"
+	"github.com/IBAX-io/go-ibax-sdk/packages/modus"
+	"github.com/IBAX-io/go-ibax-sdk/packages/request"
+	"github.com/IBAX-io/go-ibax-sdk/packages/response"
+	"github.com/IBAX-io/go-ibax-sdk/packages/rpc"
+	"github.com/IBAX-io/go-ibax-sdk/packages/transport"
+	"strconv"
+	"strings"
+)
+
+// Capabilities of a node detected by Probe
+type Capabilities struct {
+	Version    string          // version of the node
+	REST       bool            // the node serves the RESTful api at api_path
+	RPC        bool            // the node serves JSON-RPC at the root path
+	Methods    map[string]bool // JSON-RPC methods listed by rpc.modules as namespace.name, nil if the node does not list them
+	Cryptoer   string          // asymmetric algorithm of the node
+	Hasher     string          // hash algorithm of the node
+	NetworkID  int64           // network id of the node
+	Centrifugo string          // centrifugo address of GetIBAXConfig, empty if the node has none
+}
+
+// Probe detects the capabilities of the node at the api address of cnf, opts configure the transport of the probe.
+// The version, the JSON-RPC methods and the network of the node are requested, no login is needed
+func Probe(ctx context.Context, cnf config.Config, opts ...Option) (*Capabilities, error) {
+	// the probe needs no key, and the cryptoer of the key may be unknown before it
+	cnf.PrivateKey, cnf.KeySource, cnf.Signer, cnf.Token = "", nil, nil, ""
+	cnf.EnableRpc = strings.HasPrefix(cnf.ApiAddress, "ws")
+	c, err := NewClientWithOptions(cnf, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return probe(ctx, c)
+}
+
+func probe(ctx context.Context, c modus.Client) (*Capabilities, error) {
+	caps := &Capabilities{}
+	var version string
+	restErr := c.SendGetContext(ctx, "version", nil, &version)
+	if restErr == nil {
+		caps.REST, caps.Version = true, version
+	}
+
+	methods, rpcErr := rpc.Call[[]string](ctx, c, request.NamespaceRPC, "modules")
+	var e *response.RPCError
+	switch {
+	case rpcErr == nil:
+		caps.RPC = true
+		caps.Methods = make(map[string]bool, len(methods))
+		for _, v := range methods {
+			caps.Methods[v] = true
+		}
+	case errors.As(rpcErr, &e):
+		// the node answers JSON-RPC without listing its methods
+		caps.RPC = true
+	}
+	if !caps.REST && !caps.RPC {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("probe node %s failed: %w", c.GetConfig().ApiAddress, errors.Join(restErr, rpcErr))
+	}
+
+	if caps.Version == "" {
+		if err := caps.query(ctx, c, "version", "getVersion", &caps.Version); err != nil {
+			return nil, err
+		}
+	}
+	var uid response.GetUIDResult
+	if err := caps.query(ctx, c, "getuid", "getUid", &uid); err != nil {
+		return nil, err
+	}
+	caps.Cryptoer, caps.Hasher = uid.Cryptoer, uid.Hasher
+	caps.NetworkID, _ = strconv.ParseInt(uid.NetworkID, 10, 64)
+	// the node answers an error if centrifugo is not configured
+	_ = caps.query(ctx, c, "config/centrifugo", "getConfig", &caps.Centrifugo, "centrifugo")
+	return caps, nil
+}
+
+// query sends the RESTful api path if the node serves it, else the JSON-RPC method of the ibax namespace
+func (c *Capabilities) query(ctx context.Context, b modus.Base, path, method string, result any, params ...any) error {
+	if c.REST {
+		return b.SendGetContext(ctx, path, nil, result)
+	}
+	req, err := b.NewMessage(request.RequestParams{Namespace: request.NamespaceIBAX, Name: method, Params: params})
+	if err != nil {
+		return err
+	}
+	return b.GETContext(ctx, req, result)
+}
+
+// Supports reports whether the node serves the JSON-RPC method, namespace.name or a name of the ibax namespace.
+// Every method is supported by a node serving JSON-RPC without listing its methods
+func (c *Capabilities) Supports(method string) bool {
+	if !c.RPC {
+		return false
+	}
+	if c.Methods == nil {
+		return true
+	}
+	if !strings.Contains(method, request.NamespaceSeparator) {
+		method = string(request.NamespaceIBAX) + request.NamespaceSeparator + method
+	}
+	return c.Methods[method]
+}
+
+// Middleware fails the calls the node does not support with a *response.UnsupportedError before sending them
+func (c *Capabilities) Middleware() transport.Middleware {
+	return func(next transport.Handler) transport.Handler {
+		return func(ctx context.Context, call *transport.Call) error {
+			if err := c.check(call); err != nil {
+				return err
+			}
+			return next(ctx, call)
+		}
+	}
+}
+
+func (c *Capabilities) check(call *transport.Call) error {
+	if call.Backend == transport.BackendREST {
+		if !c.REST {
+			return &response.UnsupportedError{Backend: call.Backend, Method: call.Name}
+		}
+		return nil
+	}
+	if reqs, ok := call.Params.([]request.BatchRequest); ok {
+		for _, v := range reqs {
+			if v.Req != nil && !c.Supports(v.Req.Method) {
+				return &response.UnsupportedError{Backend: call.Backend, Method: v.Req.Method}
+			}
+		}
+		return nil
+	}
+	if !c.Supports(call.Name) {
+		return &response.UnsupportedError{Backend: call.Backend, Method: call.Name}
+	}
+	return nil
+}
+
+// NewAutoClient probes the node and creates a client of the JSON-RPC backend if the node serves it, else of the
+// RESTful one. The cryptoer and hasher of the node are used if cnf has none, and the calls the node does not
+// support fail fast with a *response.UnsupportedError
+func NewAutoClient(ctx context.Context, cnf config.Config, opts ...Option) (modus.Client, *Capabilities, error) {
+	caps, err := Probe(ctx, cnf, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	cnf.EnableRpc = caps.RPC
+	if cnf.Cryptoer == "" {
+		cnf.Cryptoer = caps.Cryptoer
+	}
+	if cnf.Hasher == "" {
+		cnf.Hasher = caps.Hasher
+	}
+	opts = append(append([]Option{}, opts...), transport.WithMiddleware(caps.Middleware()))
+	c, err := NewClientWithOptions(cnf, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, caps, nil
+}
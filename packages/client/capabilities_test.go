@@ -0,0 +1,69 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"github.com/IBAX-io/go-ibax-sdk/packages/client"
+	"github.com/IBAX-io/go-ibax-sdk/packages/ibaxtest"
+	"github.com/IBAX-io/go-ibax-sdk/packages/request"
+	"github.com/IBAX-io/go-ibax-sdk/packages/response"
+	"github.com/IBAX-io/go-ibax-sdk/packages/rpc"
+	"github.com/IBAX-io/go-ibax/packages/common/crypto"
+	"testing"
+)
+
+func TestNewAutoClient(t *testing.T) {
+	key, _, err := crypto.GenHexKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	node := ibaxtest.NewNode()
+	defer node.Close()
+	cnf := node.Config(key, false)
+	cnf.Cryptoer, cnf.Hasher = "", ""
+	c, caps, err := client.NewAutoClient(ctx, cnf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !caps.REST || !caps.RPC || caps.Version != ibaxtest.Version || caps.NetworkID != ibaxtest.NetworkID ||
+		caps.Cryptoer != ibaxtest.Cryptoer || caps.Hasher != ibaxtest.Hasher {
+		t.Errorf("unexpected capabilities %+v", caps)
+	}
+	if !caps.Supports("getList") || !caps.Supports("ibax.sendTx") || caps.Supports("getNothing") {
+		t.Error("unexpected supported methods")
+	}
+	if cnf := c.GetConfig(); !cnf.EnableRpc || cnf.Hasher != ibaxtest.Hasher {
+		t.Errorf("want the JSON-RPC backend and the hasher of the node, got %+v", cnf)
+	}
+	if err = c.AutoLogin(); err != nil {
+		t.Fatal(err)
+	}
+	var unsupported *response.UnsupportedError
+	_, err = rpc.Call[string](ctx, c, request.NamespaceIBAX, "getNothing")
+	if !errors.Is(err, response.NotSupportError) || !errors.As(err, &unsupported) || unsupported.Method != "ibax.getNothing" {
+		t.Errorf("expected the method unsupported, got %v", err)
+	}
+
+	node.DisableRPC()
+	c, caps, err = client.NewAutoClient(ctx, cnf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !caps.REST || caps.RPC || caps.Version != ibaxtest.Version || c.GetConfig().EnableRpc {
+		t.Errorf("want the RESTful backend, got %+v", caps)
+	}
+	if err = c.AutoLogin(); err != nil {
+		t.Fatal(err)
+	}
+	// the RESTful client sends EcosystemInfo by JSON-RPC
+	if _, err = c.EcosystemInfo(1); !errors.Is(err, response.NotSupportError) {
+		t.Errorf("expected JSON-RPC unsupported, got %v", err)
+	}
+
+	node.Close()
+	if _, err = client.Probe(ctx, cnf); err == nil {
+		t.Error("expected the probe of a stopped node to fail")
+	}
+}
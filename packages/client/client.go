@@ -5,8 +5,12 @@ import (
 	"github.com/IBAX-io/go-ibax-sdk/packages/api"
 	"github.com/IBAX-io/go-ibax-sdk/packages/modus"
 	"github.com/IBAX-io/go-ibax-sdk/packages/rpc"
+	"github.com/IBAX-io/go-ibax-sdk/packages/transport"
 )
 
+// Option configures the transport shared by both backends, see the With* functions in the transport package
+type Option = transport.Option
+
 func NewClient(config config.Config) modus.Client {
 	if config.EnableRpc {
 		return rpc.NewClient(config)
@@ -14,3 +18,13 @@ func NewClient(config config.Config) modus.Client {
 		return api.NewClient(config)
 	}
 }
+
+// NewClientWithOptions
+// like NewClient, but the http client, timeout, user agent and extra headers can be configured by opts,
+// and the init error is returned instead of exiting the process
+func NewClientWithOptions(config config.Config, opts ...Option) (modus.Client, error) {
+	if config.EnableRpc {
+		return rpc.NewClientWithOptions(config, opts...)
+	}
+	return api.NewClientWithOptions(config, opts...)
+}
@@ -0,0 +1,58 @@
+package client_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"github.com/IBAX-io/go-ibax-sdk/packages/client"
+	"github.com/IBAX-io/go-ibax-sdk/packages/ibaxtest"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/cryptoer"
+	"github.com/IBAX-io/go-ibax/packages/common/crypto"
+	"net/url"
+	"testing"
+)
+
+func TestClient_Crypto(t *testing.T) {
+	node := ibaxtest.NewNode()
+	defer node.Close()
+
+	// the process-global algorithms of another chain do not change the ones of the client
+	crypto.InitAsymAlgo("SM2")
+	crypto.InitHashAlgo("SM3")
+	defer func() {
+		crypto.InitAsymAlgo(ibaxtest.Cryptoer)
+		crypto.InitHashAlgo(ibaxtest.Hasher)
+	}()
+	sm, err := cryptoer.New("SM2", "SM3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, enableRpc := range []bool{false, true} {
+		t.Run(fmt.Sprintf("rpc=%v", enableRpc), func(t *testing.T) {
+			key, pub, err := node.Crypto().GenKeyPair()
+			if err != nil {
+				t.Fatal(err)
+			}
+			c, err := client.NewClientWithOptions(node.Config(hex.EncodeToString(key), enableRpc))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c.GetConfig().KeyId != node.Crypto().Address(pub) {
+				t.Error("expected the key id of the algorithms of the node")
+			}
+			if err = c.AutoLogin(); err != nil {
+				t.Fatal(err)
+			}
+			account := c.GetConfig().Account
+			node.SetBalance(1, account, "100")
+			form := url.Values{"Recipient": {account}, "Amount": {"10"}}
+			if _, err = c.AutoCallContract("@1TokensSend", &form, ""); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+	if data := []byte("hash"); !bytes.Equal(crypto.Hash(data), sm.Hash(data)) {
+		t.Error("expected the process-global algorithms to be unchanged")
+	}
+}
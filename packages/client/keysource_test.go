@@ -0,0 +1,53 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/IBAX-io/go-ibax-sdk/packages/client"
+	"github.com/IBAX-io/go-ibax-sdk/packages/ibaxtest"
+	"github.com/IBAX-io/go-ibax-sdk/packages/keysource"
+	"github.com/IBAX-io/go-ibax/packages/common/crypto"
+	"net/url"
+	"sync/atomic"
+	"testing"
+)
+
+func TestKeySource(t *testing.T) {
+	node := ibaxtest.NewNode()
+	defer node.Close()
+
+	for _, enableRpc := range []bool{false, true} {
+		t.Run(fmt.Sprintf("rpc=%v", enableRpc), func(t *testing.T) {
+			key, _, err := crypto.GenHexKeys()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var reads atomic.Int32
+			cnf := node.Config("", enableRpc)
+			cnf.KeySource = keysource.Func(func(ctx context.Context) ([]byte, error) {
+				reads.Add(1)
+				return keysource.Hex(key).PrivateKey(ctx)
+			})
+			c, err := client.NewClientWithOptions(cnf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = c.AutoLogin(); err != nil {
+				t.Fatal(err)
+			}
+			account := c.GetConfig().Account
+			node.SetBalance(1, account, "100")
+			form := url.Values{"Recipient": {account}, "Amount": {"10"}}
+			if _, err = c.AutoCallContract("@1TokensSend", &form, ""); err != nil {
+				t.Fatal(err)
+			}
+			if c.GetConfig().PrivateKey != "" {
+				t.Error("expected the key not to be kept in the config")
+			}
+			// Init derives the account, the login and the transaction are signed with the key read again
+			if n := reads.Load(); n != 3 {
+				t.Errorf("want the key read 3 times, got %d", n)
+			}
+		})
+	}
+}
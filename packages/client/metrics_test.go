@@ -0,0 +1,65 @@
+package client_test
+
+import (
+	"fmt"
+	"github.com/IBAX-io/go-ibax-sdk/packages/client"
+	"github.com/IBAX-io/go-ibax-sdk/packages/ibaxtest"
+	"github.com/IBAX-io/go-ibax-sdk/packages/metrics"
+	"github.com/IBAX-io/go-ibax-sdk/packages/transport"
+	"github.com/IBAX-io/go-ibax/packages/common/crypto"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestMetrics(t *testing.T) {
+	node := ibaxtest.NewNode()
+	defer node.Close()
+
+	for method, enableRpc := range map[string]bool{"contracts": false, "ibax.getContracts": true} {
+		t.Run(fmt.Sprintf("rpc=%v", enableRpc), func(t *testing.T) {
+			key, _, err := crypto.GenHexKeys()
+			if err != nil {
+				t.Fatal(err)
+			}
+			reg := metrics.NewRegistry()
+			cnf := node.Config(key, enableRpc)
+			c, err := client.NewClientWithOptions(cnf, transport.WithMetrics(reg), transport.WithReauth())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = c.AutoLogin(); err != nil {
+				t.Fatal(err)
+			}
+			node.SetBalance(1, c.GetConfig().Account, "100")
+			form := url.Values{"Recipient": {c.GetConfig().Account}, "Amount": {"1000"}}
+			result, err := c.AutoCallContract("@1TokensSend", &form, "")
+			if err != nil || result.Penalty != 1 {
+				t.Fatalf("expected a penalty, got %+v %v", result, err)
+			}
+			node.RevokeTokens()
+			// the rejected token is refreshed by a login again
+			if _, err = c.GetContracts(10, 0); err != nil {
+				t.Fatal(err)
+			}
+
+			var b strings.Builder
+			if _, err = reg.WriteTo(&b); err != nil {
+				t.Fatal(err)
+			}
+			out := b.String()
+			for _, want := range []string{
+				fmt.Sprintf("ibax_sdk_requests_total{method=%q,endpoint=%q} 2\n", method, cnf.ApiAddress),
+				fmt.Sprintf("ibax_sdk_request_errors_total{method=%q,endpoint=%q} 1\n", method, cnf.ApiAddress),
+				`ibax_sdk_token_refreshes_total{result="ok"} 1` + "\n",
+				"ibax_sdk_txs_sent_total 1\n",
+				"ibax_sdk_tx_confirmation_seconds_count 1\n",
+				"ibax_sdk_tx_penalties_total 1\n",
+			} {
+				if !strings.Contains(out, want) {
+					t.Errorf("missing %q in:\n%s", want, out)
+				}
+			}
+		})
+	}
+}
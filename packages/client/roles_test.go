@@ -0,0 +1,70 @@
+package client_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/IBAX-io/go-ibax-sdk/packages/client"
+	"github.com/IBAX-io/go-ibax-sdk/packages/ibaxtest"
+	"github.com/IBAX-io/go-ibax-sdk/packages/response"
+	"github.com/IBAX-io/go-ibax-sdk/packages/transport"
+	"github.com/IBAX-io/go-ibax/packages/common/crypto"
+	"testing"
+)
+
+func TestLoginAs(t *testing.T) {
+	node := ibaxtest.NewNode()
+	defer node.Close()
+
+	for _, enableRpc := range []bool{false, true} {
+		t.Run(fmt.Sprintf("rpc=%v", enableRpc), func(t *testing.T) {
+			key, _, err := crypto.GenHexKeys()
+			if err != nil {
+				t.Fatal(err)
+			}
+			c, err := client.NewClientWithOptions(node.Config(key, enableRpc), transport.WithReauth())
+			if err != nil {
+				t.Fatal(err)
+			}
+			account := c.GetConfig().Account
+			node.SetRole(1, account, 3, "Developer")
+			node.SetRole(1, account, 7, "Auditor")
+			node.SetRole(2, account, 9, "Admin")
+
+			roles, err := c.Roles()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(roles) != 2 || roles[0].Name != "Developer" || roles[1].RoleID() != 7 {
+				t.Fatalf("unexpected roles of the ecosystem 1 %+v", roles)
+			}
+
+			if err = c.GetUid(); err != nil {
+				t.Fatal(err)
+			}
+			ret, err := c.LoginAs("Auditor")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ret.Account != account || len(ret.Roles) != 2 || c.GetConfig().Token != ret.Token {
+				t.Errorf("unexpected login result %+v", ret)
+			}
+			if _, err = c.GetContracts(10, 0); err != nil {
+				t.Fatal(err)
+			}
+
+			// the role is kept when the token is rejected and the client logs in again
+			node.RevokeTokens()
+			if _, err = c.GetContracts(10, 0); err != nil {
+				t.Fatal(err)
+			}
+			cnf := c.GetConfig()
+			if role, ok := node.Role(cnf.Token); !ok || role != 7 || cnf.RoleId != 7 {
+				t.Errorf("want the role 7 after logging in again, got %d of the node, %d of the config", role, cnf.RoleId)
+			}
+
+			if _, err = c.LoginAs("Admin"); !errors.Is(err, response.ErrNotFound) {
+				t.Errorf("expected ErrNotFound for a role of another ecosystem, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package client_test
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/IBAX-io/go-ibax-sdk/packages/client"
+	"github.com/IBAX-io/go-ibax-sdk/packages/ibaxtest"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/converter"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/transaction"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/types"
+	"github.com/IBAX-io/go-ibax-sdk/packages/request"
+	"github.com/IBAX-io/go-ibax-sdk/packages/response"
+	"github.com/IBAX-io/go-ibax-sdk/packages/transport"
+	"github.com/IBAX-io/go-ibax/packages/common/crypto"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// lostReply delivers the sendTx requests to the node but answers them with 503, as if the reply was lost
+type lostReply struct {
+	lock  sync.Mutex
+	sends int
+}
+
+func (l *lostReply) RoundTrip(req *http.Request) (*http.Response, error) {
+	sendTx := strings.HasSuffix(req.URL.Path, "/sendTx")
+	if !sendTx && req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		sendTx = bytes.Contains(body, []byte(`"ibax.sendTx"`))
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil || !sendTx {
+		return resp, err
+	}
+	resp.Body.Close()
+	l.lock.Lock()
+	l.sends++
+	l.lock.Unlock()
+	return &http.Response{
+		StatusCode: http.StatusServiceUnavailable,
+		Status:     "503 Service Unavailable",
+		Header:     http.Header{"Content-Type": {"text/plain"}},
+		Body:       io.NopCloser(strings.NewReader("unavailable")),
+		Request:    req,
+	}, nil
+}
+
+func TestSendTxLostReply(t *testing.T) {
+	node := ibaxtest.NewNode()
+	defer node.Close()
+
+	for _, enableRpc := range []bool{false, true} {
+		t.Run(fmt.Sprintf("rpc=%v", enableRpc), func(t *testing.T) {
+			key, _, err := crypto.GenHexKeys()
+			if err != nil {
+				t.Fatal(err)
+			}
+			lost := &lostReply{}
+			policy := transport.DefaultRetryPolicy()
+			policy.InitialBackoff = time.Millisecond
+			c, err := client.NewClientWithOptions(node.Config(key, enableRpc),
+				transport.WithRoundTripper(lost), transport.WithRetryPolicy(policy))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer c.Close()
+			if err = c.AutoLogin(); err != nil {
+				t.Fatal(err)
+			}
+			cnf := c.GetConfig()
+			node.SetBalance(1, cnf.Account, "100")
+			txs := make(map[string][]byte)
+			for _, amount := range []string{"1", "2", "3", "4"} {
+				data, hash, err := transaction.NewTransaction(node.Crypto(), types.SmartTransaction{
+					Header: &types.Header{ID: 1, Time: time.Now().Unix(), EcosystemID: 1,
+						KeyID: cnf.KeyId, NetworkID: ibaxtest.NetworkID},
+					Params: map[string]any{"Recipient": cnf.Account, "Amount": amount},
+				}, converter.HexToBin(cnf.PrivateKey))
+				if err != nil {
+					t.Fatal(err)
+				}
+				txs[fmt.Sprintf("%x", hash)] = data
+			}
+
+			// a single transaction received by the node is not sent again
+			var single string
+			for k := range txs {
+				single = k
+				break
+			}
+			if _, err = c.SendTx(map[string][]byte{single: txs[single]}); err != nil {
+				t.Fatal(err)
+			}
+			if lost.sends != 1 {
+				t.Errorf("want the transaction sent once, got %d", lost.sends)
+			}
+
+			// a single transaction sent by a JSON-RPC message is not sent again either, by any backend
+			delete(txs, single)
+			for k := range txs {
+				single = k
+				break
+			}
+			msg, err := c.NewMessage(request.RequestParams{Namespace: request.NamespaceIBAX, Name: "sendTx",
+				Params: []any{map[string][]byte{single: txs[single]}}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			lost.sends = 0
+			if err = c.POST(msg, &response.SendTxResult{}); err != nil {
+				t.Fatal(err)
+			}
+			if lost.sends != 1 {
+				t.Errorf("want the JSON-RPC transaction sent once, got %d", lost.sends)
+			}
+
+			// the transactions sent together are not sent again, the node may have received some of them
+			delete(txs, single)
+			lost.sends = 0
+			if _, err = c.SendTx(txs); err == nil {
+				t.Error("expected the lost reply to fail")
+			}
+			if lost.sends != 1 {
+				t.Errorf("want the transactions sent once, got %d", lost.sends)
+			}
+		})
+	}
+}
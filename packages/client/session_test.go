@@ -0,0 +1,170 @@
+package client_test
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/IBAX-io/go-ibax-sdk/packages/client"
+	"github.com/IBAX-io/go-ibax-sdk/packages/ibaxtest"
+	"github.com/IBAX-io/go-ibax-sdk/packages/metrics"
+	"github.com/IBAX-io/go-ibax-sdk/packages/transport"
+	"github.com/IBAX-io/go-ibax/packages/common/crypto"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTokenRefresh(t *testing.T) {
+	for _, enableRpc := range []bool{false, true} {
+		t.Run(fmt.Sprintf("rpc=%v", enableRpc), func(t *testing.T) {
+			node := ibaxtest.NewNode()
+			defer node.Close()
+			node.SetTokenExpire(3 * time.Second)
+
+			key, _, err := crypto.GenHexKeys()
+			if err != nil {
+				t.Fatal(err)
+			}
+			refreshed := make(chan time.Time, 10)
+			failed := make(chan error, 10)
+			reg := metrics.NewRegistry()
+			c, err := client.NewClientWithOptions(node.Config(key, enableRpc),
+				transport.WithTokenRefresh(2*time.Second),
+				transport.OnTokenRefreshed(func(expire time.Time) { refreshed <- expire }),
+				transport.OnAuthFailed(func(err error) { failed <- err }),
+				transport.WithMetrics(reg),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer c.Close()
+			if err = c.AutoLogin(); err != nil {
+				t.Fatal(err)
+			}
+			token := c.GetConfig().Token
+
+			select {
+			case expire := <-refreshed:
+				if time.Until(expire) < 2*time.Second {
+					t.Errorf("want the expiry of the new token, got %s", expire)
+				}
+			case err = <-failed:
+				t.Fatal(err)
+			case <-time.After(5 * time.Second):
+				t.Fatal("the token was not refreshed")
+			}
+			if c.GetConfig().Token == token {
+				t.Error("expected a new token")
+			}
+			if _, err = c.GetContracts(10, 0); err != nil {
+				t.Fatal(err)
+			}
+
+			node.Close()
+			select {
+			case err = <-failed:
+			case <-time.After(5 * time.Second):
+				t.Fatal("the failed refresh was not reported")
+			}
+			var b strings.Builder
+			if _, err = reg.WriteTo(&b); err != nil {
+				t.Fatal(err)
+			}
+			if out := b.String(); !strings.Contains(out, `ibax_sdk_token_refreshes_total{result="ok"} 1`) ||
+				!strings.Contains(out, `ibax_sdk_token_refreshes_total{result="error"}`) {
+				t.Errorf("expected the refreshes to be counted:\n%s", out)
+			}
+
+			done := make(chan error)
+			go func() { done <- c.Close() }()
+			select {
+			case err = <-done:
+				if err != nil {
+					t.Error(err)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("close did not stop the session keeper")
+			}
+		})
+	}
+}
+
+// blockedLogin holds the login requests after the first pass ones until release is closed
+type blockedLogin struct {
+	pass    int32
+	started chan struct{}
+	release chan struct{}
+}
+
+func (b *blockedLogin) RoundTrip(req *http.Request) (*http.Response, error) {
+	login := strings.HasSuffix(req.URL.Path, "/login")
+	if !login && req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		login = bytes.Contains(body, []byte(`"ibax.login"`))
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	if login && atomic.AddInt32(&b.pass, -1) < 0 {
+		select {
+		case b.started <- struct{}{}:
+		default:
+		}
+		<-b.release
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestTokenRefreshKeepsToken(t *testing.T) {
+	for _, enableRpc := range []bool{false, true} {
+		t.Run(fmt.Sprintf("rpc=%v", enableRpc), func(t *testing.T) {
+			node := ibaxtest.NewNode()
+			defer node.Close()
+			node.SetTokenExpire(4 * time.Second)
+
+			key, _, err := crypto.GenHexKeys()
+			if err != nil {
+				t.Fatal(err)
+			}
+			login := &blockedLogin{pass: 1, started: make(chan struct{}, 1), release: make(chan struct{})}
+			refreshed := make(chan time.Time, 10)
+			c, err := client.NewClientWithOptions(node.Config(key, enableRpc),
+				transport.WithTokenRefresh(3*time.Second), transport.WithRoundTripper(login),
+				transport.OnTokenRefreshed(func(expire time.Time) { refreshed <- expire }))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer c.Close()
+			if err = c.AutoLogin(); err != nil {
+				t.Fatal(err)
+			}
+			token := c.GetConfig().Token
+
+			// the token is kept and used until the login of the keeper succeeds
+			select {
+			case <-login.started:
+			case <-time.After(5 * time.Second):
+				t.Fatal("the token was not refreshed")
+			}
+			if c.GetConfig().Token != token {
+				t.Error("the token was dropped before the login succeeded")
+			}
+			if _, err = c.GetContracts(10, 0); err != nil {
+				t.Errorf("want the current token to be used during the login, got %v", err)
+			}
+			close(login.release)
+			select {
+			case <-refreshed:
+			case <-time.After(5 * time.Second):
+				t.Fatal("the token was not refreshed")
+			}
+			if c.GetConfig().Token == token {
+				t.Error("expected a new token")
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/IBAX-io/go-ibax-sdk/config"
+	"github.com/IBAX-io/go-ibax-sdk/packages/modus"
+	"github.com/IBAX-io/go-ibax-sdk/packages/response"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrSessionsClosed is returned by a closed SessionManager
+var ErrSessionsClosed = errors.New("session manager is closed")
+
+// SessionKey is the ecosystem and the role of a session, RoleId 0 for no role
+type SessionKey struct {
+	Ecosystem int64
+	RoleId    int64
+}
+
+// loginTimeout bounds the shared login of a session
+const loginTimeout = time.Minute
+
+// detached keeps the values of a context without its cancellation and deadline, like context.WithoutCancel
+type detached struct {
+	parent context.Context
+}
+
+func (d detached) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (d detached) Done() <-chan struct{} {
+	return nil
+}
+
+func (d detached) Err() error {
+	return nil
+}
+
+func (d detached) Value(key any) any {
+	return d.parent.Value(key)
+}
+
+// SessionManager holds a logged in client per ecosystem and role of the key of a config. Every session has its own
+// copy of the config and token, so the sessions are used concurrently without SetConfig or logging in again
+type SessionManager struct {
+	config config.Config
+	opts   []Option
+	keys   modus.Client // queries the roles of the key, it is not logged in
+
+	lock     sync.Mutex
+	sessions map[SessionKey]*session
+	closed   bool
+}
+
+// session is ready once its login is done, client is nil if err is set
+type session struct {
+	ready  chan struct{}
+	client modus.Client
+	err    error
+}
+
+// NewSessionManager returns a SessionManager of config, the clients of the sessions are created with opts like
+// NewClientWithOptions. The ecosystem and the role of config are replaced by the ones of each session
+func NewSessionManager(config config.Config, opts ...Option) (*SessionManager, error) {
+	config.Token, config.UID, config.TokenExpireTime = "", "", 0
+	keys, err := NewClientWithOptions(config, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &SessionManager{
+		config:   config,
+		opts:     opts,
+		keys:     keys,
+		sessions: make(map[SessionKey]*session),
+	}, nil
+}
+
+// Session returns the client logged in to roleId of ecosystem, it logs in on the first call for them.
+// The concurrent calls for the same session share the login, a failed login is tried again by the next call.
+// The login is not ended by the ctx of a caller, so the other callers still get the session, it ends after loginTimeout
+func (m *SessionManager) Session(ctx context.Context, ecosystem, roleId int64) (modus.Client, error) {
+	key := SessionKey{Ecosystem: ecosystem, RoleId: roleId}
+	m.lock.Lock()
+	if m.closed {
+		m.lock.Unlock()
+		return nil, ErrSessionsClosed
+	}
+	s, ok := m.sessions[key]
+	if !ok {
+		s = &session{ready: make(chan struct{})}
+		m.sessions[key] = s
+		go m.start(detached{ctx}, key, s)
+	}
+	m.lock.Unlock()
+	select {
+	case <-s.ready:
+		return s.client, s.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// start logs in the session s of key, it is dropped if the login fails
+func (m *SessionManager) start(ctx context.Context, key SessionKey, s *session) {
+	ctx, cancel := context.WithTimeout(ctx, loginTimeout)
+	defer cancel()
+	s.client, s.err = m.login(ctx, key)
+	close(s.ready)
+	if s.err != nil {
+		m.lock.Lock()
+		if m.sessions[key] == s {
+			delete(m.sessions, key)
+		}
+		m.lock.Unlock()
+	}
+}
+
+// SessionAs is like Session with the role named roleName of the key in ecosystem,
+// the error wraps response.ErrNotFound if the key has no such role
+func (m *SessionManager) SessionAs(ctx context.Context, ecosystem int64, roleName string) (modus.Client, error) {
+	info, err := m.keys.GetKeyInfoContext(ctx, m.keys.GetConfig().Account)
+	if err != nil {
+		return nil, err
+	}
+	if eco := info.Ecosystem(ecosystem); eco != nil {
+		for _, v := range eco.Roles {
+			if v.Name == roleName {
+				return m.Session(ctx, ecosystem, v.RoleID())
+			}
+		}
+	}
+	return nil, fmt.Errorf("role %s %w in ecosystem %d", roleName, response.ErrNotFound, ecosystem)
+}
+
+// Sessions returns the keys of the logged in sessions, by ecosystem and role
+func (m *SessionManager) Sessions() []SessionKey {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	var list []SessionKey
+	for k, s := range m.sessions {
+		select {
+		case <-s.ready:
+			if s.err == nil {
+				list = append(list, k)
+			}
+		default:
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Ecosystem != list[j].Ecosystem {
+			return list[i].Ecosystem < list[j].Ecosystem
+		}
+		return list[i].RoleId < list[j].RoleId
+	})
+	return list
+}
+
+// Close closes the clients of all the sessions, it waits for the logins in progress
+func (m *SessionManager) Close() error {
+	m.lock.Lock()
+	if m.closed {
+		m.lock.Unlock()
+		return nil
+	}
+	m.closed = true
+	sessions := m.sessions
+	m.sessions = nil
+	m.lock.Unlock()
+
+	errs := []error{m.keys.Close()}
+	for _, s := range sessions {
+		<-s.ready
+		if s.client != nil {
+			errs = append(errs, s.client.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// login creates the client of key with its own copy of the config and logs it in
+func (m *SessionManager) login(ctx context.Context, key SessionKey) (modus.Client, error) {
+	cnf := m.config
+	cnf.Ecosystem, cnf.RoleId = key.Ecosystem, key.RoleId
+	c, err := NewClientWithOptions(cnf, m.opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.AutoLoginContext(ctx); err != nil {
+		c.Close()
+		return nil, err
+	}
+	return c, nil
+}
@@ -0,0 +1,145 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/IBAX-io/go-ibax-sdk/packages/client"
+	"github.com/IBAX-io/go-ibax-sdk/packages/ibaxtest"
+	"github.com/IBAX-io/go-ibax-sdk/packages/modus"
+	"github.com/IBAX-io/go-ibax-sdk/packages/response"
+	"github.com/IBAX-io/go-ibax-sdk/packages/transport"
+	"github.com/IBAX-io/go-ibax/packages/common/crypto"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSessionManager(t *testing.T) {
+	node := ibaxtest.NewNode()
+	defer node.Close()
+	node.AddEcosystem(2, "second")
+
+	for _, enableRpc := range []bool{false, true} {
+		t.Run(fmt.Sprintf("rpc=%v", enableRpc), func(t *testing.T) {
+			key, _, err := crypto.GenHexKeys()
+			if err != nil {
+				t.Fatal(err)
+			}
+			m, err := client.NewSessionManager(node.Config(key, enableRpc))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer m.Close()
+			ctx := context.Background()
+
+			// the sessions of the same key are logged in once, and used at the same time
+			clients := make([]modus.Client, 20)
+			var wg sync.WaitGroup
+			for i := range clients {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					c, err := m.Session(ctx, int64(i%2+1), 0)
+					if err != nil {
+						t.Error(err)
+						return
+					}
+					if _, err = c.GetUidResponseContext(ctx); err != nil {
+						t.Error(err)
+					}
+					clients[i] = c
+				}(i)
+			}
+			wg.Wait()
+			if t.Failed() {
+				return
+			}
+			for i, c := range clients {
+				if c != clients[i%2] {
+					t.Fatal("expected one client per session")
+				}
+			}
+			for i, c := range clients[:2] {
+				ret, err := c.GetUidResponse()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if want := fmt.Sprint(i + 1); ret.EcosystemID != want {
+					t.Errorf("want a token of the ecosystem %s, got %s", want, ret.EcosystemID)
+				}
+			}
+
+			account := clients[0].GetConfig().Account
+			node.SetRole(1, account, 3, "Developer")
+			dev, err := m.SessionAs(ctx, 1, "Developer")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cnf := dev.GetConfig(); cnf.RoleId != 3 || cnf.Token == clients[0].GetConfig().Token {
+				t.Errorf("unexpected config of the role session %+v", cnf)
+			}
+			if _, err = m.SessionAs(ctx, 2, "Developer"); !errors.Is(err, response.ErrNotFound) {
+				t.Errorf("expected ErrNotFound, got %v", err)
+			}
+
+			want := []client.SessionKey{{Ecosystem: 1}, {Ecosystem: 1, RoleId: 3}, {Ecosystem: 2}}
+			if got := m.Sessions(); !reflect.DeepEqual(got, want) {
+				t.Errorf("want sessions %v, got %v", want, got)
+			}
+			if err = m.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if _, err = m.Session(ctx, 1, 0); !errors.Is(err, client.ErrSessionsClosed) {
+				t.Errorf("expected ErrSessionsClosed, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSessionManager_CanceledCaller(t *testing.T) {
+	node := ibaxtest.NewNode()
+	defer node.Close()
+
+	for _, enableRpc := range []bool{false, true} {
+		t.Run(fmt.Sprintf("rpc=%v", enableRpc), func(t *testing.T) {
+			key, _, err := crypto.GenHexKeys()
+			if err != nil {
+				t.Fatal(err)
+			}
+			login := &blockedLogin{started: make(chan struct{}, 1), release: make(chan struct{})}
+			m, err := client.NewSessionManager(node.Config(key, enableRpc), transport.WithRoundTripper(login))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer m.Close()
+
+			// the first caller gives up during the login, the other callers still get the session
+			ctx, cancel := context.WithCancel(context.Background())
+			first := make(chan error, 1)
+			go func() {
+				_, err := m.Session(ctx, 1, 0)
+				first <- err
+			}()
+			<-login.started
+			cancel()
+			select {
+			case err = <-first:
+				if !errors.Is(err, context.Canceled) {
+					t.Errorf("expected the canceled caller to fail, got %v", err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("the canceled caller waits for the login")
+			}
+			close(login.release)
+			c, err := m.Session(context.Background(), 1, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err = c.GetContracts(10, 0); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
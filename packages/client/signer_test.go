@@ -0,0 +1,152 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/IBAX-io/go-ibax-sdk/packages/client"
+	"github.com/IBAX-io/go-ibax-sdk/packages/ibaxtest"
+	"github.com/IBAX-io/go-ibax-sdk/packages/logger"
+	"github.com/IBAX-io/go-ibax-sdk/packages/request"
+	"github.com/IBAX-io/go-ibax-sdk/packages/signer"
+	"github.com/IBAX-io/go-ibax-sdk/packages/transport"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// failingSigner fails to sign once fail is set
+type failingSigner struct {
+	signer.Signer
+	fail *atomic.Bool
+}
+
+func (s failingSigner) Sign(ctx context.Context, hash []byte) ([]byte, error) {
+	if s.fail.Load() {
+		return nil, errors.New("signer unavailable")
+	}
+	return s.Signer.Sign(ctx, hash)
+}
+
+// errorLogger keeps the messages of the errors logged
+type errorLogger struct {
+	lock   sync.Mutex
+	errors []string
+}
+
+func (l *errorLogger) Debug(string, ...any) {}
+func (l *errorLogger) Info(string, ...any)  {}
+func (l *errorLogger) Warn(string, ...any)  {}
+func (l *errorLogger) Error(msg string, _ ...any) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.errors = append(l.errors, msg)
+}
+
+func (l *errorLogger) messages() []string {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return append([]string(nil), l.errors...)
+}
+
+// countingSigner counts the signatures of a signer
+type countingSigner struct {
+	signer.Signer
+	signs *atomic.Int32
+}
+
+func (s countingSigner) Sign(ctx context.Context, hash []byte) ([]byte, error) {
+	s.signs.Add(1)
+	return s.Signer.Sign(ctx, hash)
+}
+
+func TestRemoteSigner(t *testing.T) {
+	node := ibaxtest.NewNode()
+	defer node.Close()
+
+	for _, enableRpc := range []bool{false, true} {
+		t.Run(fmt.Sprintf("rpc=%v", enableRpc), func(t *testing.T) {
+			// the key is kept by the signer server only
+			key, pub, err := node.Crypto().GenKeyPair()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var signs atomic.Int32
+			srv := httptest.NewServer(signer.Handler(countingSigner{signer.Key(node.Crypto(), key), &signs}))
+			defer srv.Close()
+
+			cnf := node.Config("", enableRpc)
+			cnf.Signer = &signer.Remote{URL: srv.URL}
+			c, err := client.NewClientWithOptions(cnf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c.GetConfig().KeyId != node.Crypto().Address(pub) {
+				t.Fatal("expected the account of the public key of the signer")
+			}
+			if err = c.AutoLogin(); err != nil {
+				t.Fatal(err)
+			}
+			account := c.GetConfig().Account
+			node.SetBalance(1, account, "1000")
+			form := url.Values{"Recipient": {account}, "Amount": {"10"}}
+			if _, err = c.AutoCallContract("@1TokensSend", &form, ""); err != nil {
+				t.Fatal(err)
+			}
+			if _, err = c.AutoCallUtxo(request.TypeContractToUTXO, url.Values{"amount": {"200"}}, ""); err != nil {
+				t.Fatal(err)
+			}
+			if _, utxo := node.Balance(1, account); utxo != "200" {
+				t.Errorf("want the utxo balance 200, got %s", utxo)
+			}
+			if n := signs.Load(); n != 3 {
+				t.Errorf("want the login and the 2 transactions signed remotely, got %d", n)
+			}
+		})
+	}
+}
+
+func TestSignerFailureLogged(t *testing.T) {
+	node := ibaxtest.NewNode()
+	defer node.Close()
+	global := &errorLogger{}
+	logger.SetDefault(global)
+	defer logger.SetDefault(nil)
+
+	for _, enableRpc := range []bool{false, true} {
+		t.Run(fmt.Sprintf("rpc=%v", enableRpc), func(t *testing.T) {
+			key, _, err := node.Crypto().GenKeyPair()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var fail atomic.Bool
+			srv := httptest.NewServer(signer.Handler(failingSigner{signer.Key(node.Crypto(), key), &fail}))
+			defer srv.Close()
+
+			cnf := node.Config("", enableRpc)
+			cnf.Signer = &signer.Remote{URL: srv.URL}
+			l := &errorLogger{}
+			c, err := client.NewClientWithOptions(cnf, transport.WithLogger(l))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = c.AutoLogin(); err != nil {
+				t.Fatal(err)
+			}
+			fail.Store(true)
+			form := url.Values{"Recipient": {c.GetConfig().Account}, "Amount": {"10"}}
+			if _, err = c.AutoCallContract("@1TokensSend", &form, ""); err == nil {
+				t.Fatal("expected the signing to fail")
+			}
+			// the signing failure is logged by the client, not by the global logger
+			if got := l.messages(); len(got) == 0 || got[0] != "signing by node private key" {
+				t.Errorf("want the signing failure logged by the client, got %v", got)
+			}
+			if got := global.messages(); len(got) != 0 {
+				t.Errorf("want nothing logged globally, got %v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package client_test
+
+import (
+	"fmt"
+	"github.com/IBAX-io/go-ibax-sdk/packages/client"
+	"github.com/IBAX-io/go-ibax-sdk/packages/ibaxtest"
+	"github.com/IBAX-io/go-ibax-sdk/packages/request"
+	"github.com/IBAX-io/go-ibax-sdk/packages/tracing"
+	"github.com/IBAX-io/go-ibax-sdk/packages/transport"
+	"github.com/IBAX-io/go-ibax/packages/common/crypto"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+)
+
+// headerRecorder keeps the traceparent headers sent to the node
+type headerRecorder struct {
+	lock    sync.Mutex
+	parents map[string]bool
+}
+
+func (h *headerRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	h.lock.Lock()
+	h.parents[req.Header.Get(tracing.TraceParentHeader)] = true
+	h.lock.Unlock()
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestTracing(t *testing.T) {
+	node := ibaxtest.NewNode()
+	defer node.Close()
+	node.SetTable("members", map[string]string{"id": "1", "member_name": "founder"})
+
+	for _, enableRpc := range []bool{false, true} {
+		t.Run(fmt.Sprintf("rpc=%v", enableRpc), func(t *testing.T) {
+			key, _, err := crypto.GenHexKeys()
+			if err != nil {
+				t.Fatal(err)
+			}
+			tracer := tracing.NewMemory()
+			headers := &headerRecorder{parents: make(map[string]bool)}
+			c, err := client.NewClientWithOptions(node.Config(key, enableRpc),
+				transport.WithTracer(tracer), transport.WithRoundTripper(headers))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = c.AutoLogin(); err != nil {
+				t.Fatal(err)
+			}
+			node.SetBalance(1, c.GetConfig().Account, "100")
+			form := url.Values{"Recipient": {c.GetConfig().Account}, "Amount": {"1"}}
+			if _, err = c.AutoCallContract("@1TokensSend", &form, ""); err != nil {
+				t.Fatal(err)
+			}
+
+			spans := make(map[string]tracing.SpanData)
+			for _, v := range tracer.Spans() {
+				spans[v.Name] = v
+			}
+			root := spans["AutoCallContract"]
+			for name, parent := range map[string]string{"GetContractInfo": "AutoCallContract", "SignTx": "AutoCallContract",
+				"SendTx": "AutoCallContract", "TxStatus": "AutoCallContract", "TxStatusPoll": "TxStatus"} {
+				span, ok := spans[name]
+				if !ok {
+					t.Errorf("missing span %s", name)
+					continue
+				}
+				if span.SpanContext.TraceID != root.SpanContext.TraceID || span.ParentID != spans[parent].SpanContext.SpanID {
+					t.Errorf("%s must be a child of %s", name, parent)
+				}
+			}
+			if root.Attributes["contract"] != "@1TokensSend" || root.Err != nil {
+				t.Errorf("unexpected root span %+v", root)
+			}
+			for _, name := range []string{"GetContractInfo", "SendTx", "TxStatusPoll"} {
+				if !headers.parents[spans[name].SpanContext.TraceParent()] {
+					t.Errorf("the trace context of %s is not sent to the node", name)
+				}
+			}
+
+			tracer.Reset()
+			_, err = c.GetList(request.GetList{ListForm: request.ListForm{Name: "missing"}})
+			if spans := tracer.Spans(); err == nil || len(spans) != 1 || spans[0].Name != "GetList" || spans[0].Err == nil {
+				t.Errorf("want a failed GetList span, got %+v", spans)
+			}
+		})
+	}
+}
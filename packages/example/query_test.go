@@ -1,8 +1,10 @@
 package example
 
 import (
+	"context"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/IBAX-io/go-ibax-sdk/packages/client"
 	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/converter"
@@ -12,6 +14,7 @@ import (
 	"github.com/bitly/go-simplejson"
 	"strconv"
 	"testing"
+	"time"
 )
 
 func TestQuery_EcosystemCount(t *testing.T) {
@@ -182,6 +185,36 @@ func TestQuery_GetList(t *testing.T) {
 	fmt.Printf("%+v\n", *v)
 }
 
+func TestQuery_GetListContext(t *testing.T) {
+	c := client.NewClient(cnf)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := c.AutoLoginContext(ctx)
+	if err != nil {
+		t.Errorf("auto login failed: %s", err.Error())
+		return
+	}
+	var req request.GetList
+	req.Name = "keys"
+	req.Columns = "id,amount,ecosystem"
+	req.Offset = 0
+	req.Limit = 10
+
+	v, er := c.GetListContext(ctx, req)
+	if er != nil {
+		t.Errorf("get list failed :%s", er.Error())
+		return
+	}
+	fmt.Printf("%+v\n", *v)
+
+	canceled, stop := context.WithCancel(context.Background())
+	stop()
+	_, er = c.GetListContext(canceled, req)
+	if !errors.Is(er, context.Canceled) {
+		t.Errorf("get list with canceled context: want %v, got %v", context.Canceled, er)
+	}
+}
+
 func TestQuery_GetRow(t *testing.T) {
 	c := client.NewClient(cnf)
 	c.AutoLogin()
@@ -0,0 +1,218 @@
+package ibaxtest
+
+import (
+	"fmt"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/converter"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/utils"
+	"github.com/IBAX-io/go-ibax-sdk/packages/response"
+	"github.com/IBAX-io/go-ibax/packages/common/crypto"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type contractInfo struct {
+	ID       uint32  `json:"id"`
+	StateID  uint32  `json:"state"`
+	TableID  string  `json:"tableid"`
+	WalletID string  `json:"walletid"`
+	TokenID  string  `json:"tokenid"`
+	Address  string  `json:"address"`
+	Fields   []Field `json:"fields"`
+	Name     string  `json:"name"`
+}
+
+// getUID returns the expiry of a logged in token, or a new uid and its token
+func (n *Node) getUID(authorization string) *response.GetUIDResult {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	ret := &response.GetUIDResult{NetworkID: strconv.Itoa(NetworkID), Cryptoer: Cryptoer, Hasher: Hasher}
+	if s, err := n.loggedIn(authorization); err == nil {
+		ret.Expire = time.Until(s.expire).Round(time.Second).String()
+		ret.EcosystemID = strconv.FormatInt(s.ecosystem, 10)
+		ret.KeyID = strconv.FormatInt(s.keyID, 10)
+		ret.Address = converter.AddressToString(s.keyID)
+		return ret
+	}
+	token, s := n.newSession()
+	ret.Token, ret.UID = token, s.uid
+	return ret
+}
+
+// login checks the signature of the uid of the token by publicKey and returns a login token
+func (n *Node) login(authorization string, publicKey, signature []byte, ecosystem, roleID int64) (*response.LoginResult, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	s := n.session(authorization)
+	if s == nil {
+		return nil, errUnauthorized
+	}
+	if len(publicKey) == 0 || len(signature) == 0 {
+		return nil, invalidParams("public key and signature are required")
+	}
+	forSign := fmt.Sprintf("LOGIN%d%s", NetworkID, s.uid)
+	if ok, err := utils.CheckSignWith(provider, [][]byte{crypto.CutPub(publicKey)}, []byte(forSign), signature, true); !ok || err != nil {
+		return nil, invalidParams("signature is incorrect")
+	}
+	if ecosystem == 0 {
+		ecosystem = 1
+	}
+	keyID := provider.Address(publicKey)
+	w := wallet{ecosystem: ecosystem, keyID: keyID}
+	n.members[w] = true
+	var roles []response.LoginRole
+	found := roleID == 0
+	for _, v := range n.roles[w] {
+		roles = append(roles, response.LoginRole{RoleId: v.RoleID(), RoleName: v.Name})
+		found = found || v.RoleID() == roleID
+	}
+	if !found {
+		return nil, invalidParams(fmt.Sprintf("role %d is not a role of the key", roleID))
+	}
+
+	token, logged := n.newSession()
+	logged.uid, logged.keyID, logged.ecosystem, logged.roleID = s.uid, keyID, ecosystem, roleID
+	logged.expire = time.Now().Add(n.tokenExpire)
+	return &response.LoginResult{
+		Token:       token,
+		EcosystemID: strconv.FormatInt(ecosystem, 10),
+		KeyID:       strconv.FormatInt(keyID, 10),
+		Account:     converter.AddressToString(keyID),
+		Timestamp:   strconv.FormatInt(time.Now().Unix(), 10),
+		Roles:       roles,
+	}, nil
+}
+
+func (n *Node) keyInfo(account string) (*response.KeyInfoResult, error) {
+	keyID := converter.StringToAddress(account)
+	if keyID == 0 {
+		return nil, invalidWallet(account)
+	}
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	ret := &response.KeyInfoResult{Account: converter.AddressToString(keyID)}
+	var ecosystems []int64
+	for w := range n.members {
+		if w.keyID == keyID {
+			ecosystems = append(ecosystems, w.ecosystem)
+		}
+	}
+	sort.Slice(ecosystems, func(i, j int) bool { return ecosystems[i] < ecosystems[j] })
+	for _, v := range ecosystems {
+		ret.Ecosystems = append(ret.Ecosystems, &response.KeyEcosystemInfo{
+			Ecosystem: strconv.FormatInt(v, 10),
+			Name:      n.ecosystems[v],
+			Digits:    Digits,
+			Roles:     n.roles[wallet{ecosystem: v, keyID: keyID}],
+		})
+	}
+	return ret, nil
+}
+
+func (n *Node) balance(account string, ecosystem int64) (*response.TokenBalanceResult, error) {
+	keyID := converter.StringToAddress(account)
+	if keyID == 0 {
+		return nil, invalidWallet(account)
+	}
+	if ecosystem == 0 {
+		ecosystem = 1
+	}
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	w := wallet{ecosystem: ecosystem, keyID: keyID}
+	return &response.TokenBalanceResult{
+		Amount:      n.accounts[w].String(),
+		Digits:      Digits,
+		Total:       n.accounts[w].Add(n.utxos[w]).String(),
+		Utxo:        n.utxos[w].String(),
+		TokenSymbol: TokenSymbol,
+	}, nil
+}
+
+func (n *Node) contractInfo(name string, ecosystem int64) (*contractInfo, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	c := n.contract(name)
+	if c == nil {
+		return nil, notFound("Contract %s has not been found", name)
+	}
+	return &contractInfo{
+		ID:       c.id,
+		StateID:  uint32(ecosystem),
+		TableID:  strconv.FormatUint(uint64(c.id), 10),
+		WalletID: "0",
+		TokenID:  "1",
+		Address:  converter.AddressToString(0),
+		Fields:   c.Fields,
+		Name:     fmt.Sprintf("@%d%s", ecosystem, c.Name),
+	}, nil
+}
+
+func (n *Node) contractList(limit, offset int) *response.ListResult {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	rows := make([]map[string]string, len(n.contracts))
+	for k, c := range n.contracts {
+		rows[k] = map[string]string{"id": strconv.FormatUint(uint64(c.id), 10), "name": c.Name, "active": "true"}
+	}
+	return page(rows, limit, offset, "")
+}
+
+func (n *Node) row(name string, id int64, columns string) (*response.RowResult, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	rows, ok := n.tables[name]
+	if !ok {
+		return nil, notFound("Table %s has not been found", name)
+	}
+	for _, v := range rows {
+		if v["id"] == strconv.FormatInt(id, 10) {
+			return &response.RowResult{Value: selectColumns(v, columns)}, nil
+		}
+	}
+	return nil, notFound("Row %d of %s has not been found", id, name)
+}
+
+// page returns limit rows from offset, limit 0 means 25 like the node
+func page(rows []map[string]string, limit, offset int, columns string) *response.ListResult {
+	if limit <= 0 {
+		limit = 25
+	}
+	ret := &response.ListResult{Count: int64(len(rows)), List: []map[string]string{}}
+	for k := offset; k < len(rows) && k < offset+limit; k++ {
+		ret.List = append(ret.List, selectColumns(rows[k], columns))
+	}
+	return ret
+}
+
+// selectColumns returns the comma separated columns and the id of row, all the columns if columns is empty or *
+func selectColumns(row map[string]string, columns string) map[string]string {
+	if columns == "" || columns == "*" {
+		return row
+	}
+	ret := map[string]string{"id": row["id"]}
+	for _, v := range strings.Split(columns, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			ret[v] = row[v]
+		}
+	}
+	return ret
+}
+
+// detailedBlocks returns count blocks from blockID
+func (n *Node) detailedBlocks(blockID, count int64) map[int64]response.BlockDetailedInfo {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if count <= 0 {
+		count = 25
+	}
+	if blockID < 1 {
+		blockID = 1
+	}
+	ret := make(map[int64]response.BlockDetailedInfo)
+	for id := blockID; id < blockID+count && id <= int64(len(n.blocks)); id++ {
+		ret[id] = n.blocks[id-1].detailed()
+	}
+	return ret
+}
@@ -0,0 +1,267 @@
+package ibaxtest
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/converter"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/transaction"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/types"
+	"github.com/IBAX-io/go-ibax-sdk/packages/request"
+	"github.com/IBAX-io/go-ibax-sdk/packages/response"
+	"github.com/vmihailenco/msgpack/v5"
+	"sort"
+	"strconv"
+	"time"
+)
+
+type txError struct {
+	Type  string `json:"type,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+type txStatus struct {
+	BlockID string   `json:"blockid"`
+	Message *txError `json:"errmsg,omitempty"`
+	Result  string   `json:"result"`
+	Penalty int64    `json:"penalty"`
+}
+
+// txRecord is a transaction received by the node, a rejected one has no block
+type txRecord struct {
+	hash     string
+	tx       *types.SmartTransaction
+	txType   byte
+	contract string
+	size     int
+	time     int64
+	block    *block
+	result   string
+	err      *txError
+	penalty  int64
+}
+
+type block struct {
+	id   int64
+	hash []byte
+	time int64
+	txs  []*txRecord
+}
+
+// sendTx receives the transactions by hex hash, the accepted ones are applied in a new block
+func (n *Node) sendTx(txs map[string][]byte) (map[string]string, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	hashes := make([]string, 0, len(txs))
+	for hash := range txs {
+		hashes = append(hashes, hash)
+	}
+	sort.Strings(hashes)
+
+	var accepted []*txRecord
+	for _, hash := range hashes {
+		if _, ok := n.txs[hash]; ok {
+			continue
+		}
+		rec, parser, err := n.decodeTx(hash, txs[hash])
+		if err != nil {
+			return nil, err
+		}
+		n.txs[hash] = rec
+		if err := n.verifyTx(rec, parser); err != nil {
+			rec.err = &txError{Type: "error", Error: err.Error()}
+			continue
+		}
+		accepted = append(accepted, rec)
+	}
+	if len(accepted) > 0 {
+		n.mint(accepted)
+	}
+
+	ret := make(map[string]string, len(hashes))
+	for _, hash := range hashes {
+		ret[hash] = hash
+	}
+	return ret, nil
+}
+
+// decodeTx decodes the data of a transaction as built by pkg/transaction
+func (n *Node) decodeTx(hash string, data []byte) (*txRecord, *transaction.SmartTransactionParser, error) {
+	if len(data) < 2 {
+		return nil, nil, invalidParams("transaction %s is empty", hash)
+	}
+	parser := transaction.NewParser(provider)
+	if err := msgpack.Unmarshal(data[1:], parser); err != nil {
+		return nil, nil, invalidParams("transaction %s decode failed:%s", hash, err.Error())
+	}
+	tx := new(types.SmartTransaction)
+	if err := tx.Unmarshal(parser.Payload); err != nil {
+		return nil, nil, invalidParams("transaction %s payload decode failed:%s", hash, err.Error())
+	}
+	if tx.Header == nil || data[0] != tx.TxType() {
+		return nil, nil, invalidParams("transaction %s is malformed", hash)
+	}
+	if !bytes.Equal(parser.Hash, provider.DoubleHash(parser.Payload)) || hex.EncodeToString(parser.Hash) != hash {
+		return nil, nil, hashWrong(hash)
+	}
+	parser.TxSmart = tx
+	return &txRecord{hash: hash, tx: tx, txType: data[0], size: len(data), time: tx.Time}, parser, nil
+}
+
+// verifyTx checks the signature and header of a transaction and registers its key in the ecosystem
+func (n *Node) verifyTx(rec *txRecord, parser *transaction.SmartTransactionParser) error {
+	if err := parser.Validate(); err != nil {
+		return fmt.Errorf("signature is invalid:%s", err.Error())
+	}
+	tx := rec.tx
+	if tx.NetworkID != NetworkID {
+		return fmt.Errorf("network id %d is wrong", tx.NetworkID)
+	}
+	if tx.KeyID != provider.Address(tx.PublicKey) {
+		return fmt.Errorf("key id %d does not match the public key", tx.KeyID)
+	}
+	if rec.txType == types.SmartContractTxType {
+		c := n.contractByID(tx.ID)
+		if c == nil {
+			return fmt.Errorf("contract %d does not exist", tx.ID)
+		}
+		rec.contract = fmt.Sprintf("@%d%s", tx.EcosystemID, c.Name)
+	}
+	n.members[wallet{ecosystem: tx.EcosystemID, keyID: tx.KeyID}] = true
+	return nil
+}
+
+// mint applies txs in a new block, the lock is held
+func (n *Node) mint(txs []*txRecord) {
+	b := &block{id: int64(len(n.blocks)) + 1, time: time.Now().Unix(), txs: txs}
+	var buf []byte
+	if len(n.blocks) > 0 {
+		buf = append(buf, n.blocks[len(n.blocks)-1].hash...)
+	}
+	buf = append(buf, strconv.FormatInt(b.time, 10)...)
+	for _, rec := range txs {
+		buf = append(buf, rec.hash...)
+		rec.block = b
+		result, err := n.apply(rec)
+		if err != nil {
+			rec.penalty = 1
+			rec.err = &txError{Type: "error", Error: err.Error()}
+			continue
+		}
+		rec.result = result
+	}
+	b.hash = provider.DoubleHash(buf)
+	n.blocks = append(n.blocks, b)
+}
+
+func (n *Node) apply(rec *txRecord) (string, error) {
+	tx := rec.tx
+	switch {
+	case tx.UTXO != nil:
+		return "", n.transfer(n.utxos, n.utxos, tx.EcosystemID, tx.KeyID, tx.UTXO.ToID, tx.UTXO.Value)
+	case tx.TransferSelf != nil:
+		src, dst := n.accounts, n.utxos
+		if tx.TransferSelf.Source == request.DestinationUtxo {
+			src, dst = dst, src
+		}
+		return "", n.transfer(src, dst, tx.EcosystemID, tx.KeyID, tx.KeyID, tx.TransferSelf.Value)
+	}
+	c := n.contractByID(tx.ID)
+	for _, f := range c.Fields {
+		if _, ok := tx.Params[f.Name]; !ok && !f.Optional {
+			return "", fmt.Errorf("%s is undefined", f.Name)
+		}
+	}
+	if c.Action == nil {
+		return "", nil
+	}
+	return c.Action(&Tx{Hash: rec.hash, KeyID: tx.KeyID, Ecosystem: tx.EcosystemID, Params: tx.Params, node: n})
+}
+
+// txStatus returns the status of the transactions, the lock is held
+func (n *Node) txStatus(hashes []string) (map[string]*txStatus, error) {
+	ret := make(map[string]*txStatus, len(hashes))
+	for _, hash := range hashes {
+		rec, ok := n.txs[hash]
+		if !ok {
+			return nil, hashNotFound(hash)
+		}
+		status := &txStatus{Result: rec.result, Penalty: rec.penalty, Message: rec.err}
+		if rec.block != nil {
+			status.BlockID = strconv.FormatInt(rec.block.id, 10)
+		}
+		ret[hash] = status
+	}
+	return ret, nil
+}
+
+// txInfo returns the block id, confirmations and details of a transaction, the lock is held
+func (n *Node) txInfo(hash string, contractInfo bool) (blockID int64, confirm int, info *response.TxInfo) {
+	rec, ok := n.txs[hash]
+	if !ok || rec.block == nil {
+		return
+	}
+	blockID = rec.block.id
+	confirm = len(n.blocks) - int(blockID)
+	if !contractInfo {
+		return
+	}
+	info = &response.TxInfo{
+		BlockId:      blockID,
+		BlockHash:    hex.EncodeToString(rec.block.hash),
+		Address:      converter.AddressToString(rec.tx.KeyID),
+		Ecosystem:    rec.tx.EcosystemID,
+		Hash:         hash,
+		Expedite:     rec.tx.Expedite,
+		ContractName: rec.contract,
+		Params:       rec.tx.Params,
+		CreatedAt:    rec.time,
+		Size:         strconv.Itoa(rec.size) + " B",
+		Status:       rec.penalty,
+	}
+	return
+}
+
+// block returns the block of id, the lock is held
+func (n *Node) block(id int64) (*block, error) {
+	if id < 1 || id > int64(len(n.blocks)) {
+		return nil, notFound("block %d has not been found", id)
+	}
+	return n.blocks[id-1], nil
+}
+
+// blockByHash returns the block of a hex hash, the lock is held
+func (n *Node) blockByHash(hash string) (*block, error) {
+	for _, b := range n.blocks {
+		if hex.EncodeToString(b.hash) == hash {
+			return b, nil
+		}
+	}
+	return nil, notFound("block %s has not been found", hash)
+}
+
+func (b *block) detailed() response.BlockDetailedInfo {
+	info := response.BlockDetailedInfo{
+		Header:  response.BlockHeaderInfo{BlockID: b.id, Time: b.time, Version: 1},
+		Hash:    hex.EncodeToString(b.hash),
+		Time:    b.time,
+		TxCount: int32(len(b.txs)),
+	}
+	var size int
+	for _, rec := range b.txs {
+		size += rec.size
+		hash, _ := hex.DecodeString(rec.hash)
+		info.Transactions = append(info.Transactions, response.TxDetailedInfo{
+			Hash:         hash,
+			ContractName: rec.contract,
+			Params:       rec.tx.Params,
+			KeyID:        rec.tx.KeyID,
+			Time:         rec.time,
+			Type:         rec.txType,
+			Size:         strconv.Itoa(rec.size) + " B",
+		})
+	}
+	info.Size = strconv.Itoa(size) + " B"
+	return info
+}
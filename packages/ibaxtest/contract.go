@@ -0,0 +1,117 @@
+package ibaxtest
+
+import (
+	"fmt"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/converter"
+	"github.com/shopspring/decimal"
+	"regexp"
+)
+
+// Field is a data field of a contract
+type Field struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"` // bool, int, address, float, money, string, map, file, bytes or array
+	Optional bool   `json:"optional"`
+}
+
+// Contract of the node, it is available in every ecosystem
+type Contract struct {
+	Name   string // name without the @ecosystem prefix
+	Fields []Field
+	// Action applies a call in a block and returns the result of the transaction,
+	// an error penalizes the transaction. nil accepts every call
+	Action func(tx *Tx) (string, error)
+
+	id uint32
+}
+
+// Tx is a contract call applied in a block
+type Tx struct {
+	Hash      string
+	KeyID     int64
+	Ecosystem int64
+	Params    map[string]any
+
+	node *Node
+}
+
+// Transfer moves amount, in the smallest unit, from the contract account of the sender to recipient
+func (tx *Tx) Transfer(recipient int64, amount string) error {
+	return tx.node.transfer(tx.node.accounts, tx.node.accounts, tx.Ecosystem, tx.KeyID, recipient, amount)
+}
+
+// TokensSend is the contract sending tokens from the contract account of the sender,
+// the fields are Recipient (account address), Amount (smallest unit) and Comment
+func TokensSend() *Contract {
+	return &Contract{
+		Name: "TokensSend",
+		Fields: []Field{
+			{Name: "Recipient", Type: "string"},
+			{Name: "Amount", Type: "money"},
+			{Name: "Comment", Type: "string", Optional: true},
+		},
+		Action: func(tx *Tx) (string, error) {
+			recipient := fmt.Sprint(tx.Params["Recipient"])
+			toID := converter.StringToAddress(recipient)
+			if toID == 0 && recipient != "0000-0000-0000-0000-0000" {
+				return "", fmt.Errorf("recipient %s is invalid", recipient)
+			}
+			return "", tx.Transfer(toID, fmt.Sprint(tx.Params["Amount"]))
+		},
+	}
+}
+
+// AddContract registers c, a contract of the same name is replaced
+func (n *Node) AddContract(c *Contract) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	for k, v := range n.contracts {
+		if v.Name == c.Name {
+			c.id = v.id
+			n.contracts[k] = c
+			return
+		}
+	}
+	c.id = uint32(len(n.contracts) + 1)
+	n.contracts = append(n.contracts, c)
+}
+
+var ecosystemPrefix = regexp.MustCompile(`^@\d+`)
+
+// contract returns the contract of name, with or without the @ecosystem prefix, the lock is held
+func (n *Node) contract(name string) *Contract {
+	name = ecosystemPrefix.ReplaceAllString(name, "")
+	for _, v := range n.contracts {
+		if v.Name == name {
+			return v
+		}
+	}
+	return nil
+}
+
+func (n *Node) contractByID(id uint32) *Contract {
+	if id == 0 || int(id) > len(n.contracts) {
+		return nil
+	}
+	return n.contracts[id-1]
+}
+
+// transfer moves amount of ecosystem from the balance in src to the balance in dst,
+// recipient 0 burns the tokens, the lock is held
+func (n *Node) transfer(src, dst map[wallet]decimal.Decimal, ecosystem, sender, recipient int64, amount string) error {
+	value, err := decimal.NewFromString(amount)
+	if err != nil || !value.IsPositive() {
+		return fmt.Errorf("amount %s is invalid", amount)
+	}
+	from := wallet{ecosystem: ecosystem, keyID: sender}
+	if src[from].LessThan(value) {
+		return fmt.Errorf("not enough tokens: balance %s, amount %s", src[from], value)
+	}
+	src[from] = src[from].Sub(value)
+	if recipient != 0 {
+		to := wallet{ecosystem: ecosystem, keyID: recipient}
+		dst[to] = dst[to].Add(value)
+		n.members[to] = true
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package ibaxtest
+
+import (
+	"fmt"
+	"github.com/IBAX-io/go-ibax-sdk/packages/response"
+	"net/http"
+)
+
+// apiError is an error of both apis, it maps to the error codes of a go-ibax node
+type apiError struct {
+	status  int    // http status of the RESTful api
+	code    string // error code of the RESTful api
+	rpcCode int    // JSON-RPC error code
+	msg     string
+}
+
+func (e *apiError) Error() string {
+	return e.msg
+}
+
+var errUnauthorized = &apiError{status: http.StatusUnauthorized, code: "E_UNAUTHORIZED",
+	rpcCode: response.RPCCodeUnauthorized, msg: "Unauthorized"}
+
+func notFound(format string, args ...any) error {
+	return &apiError{status: http.StatusNotFound, code: "E_NOTFOUND",
+		rpcCode: response.RPCCodeNotFound, msg: fmt.Sprintf(format, args...)}
+}
+
+func hashNotFound(hash string) error {
+	return &apiError{status: http.StatusBadRequest, code: "E_HASHNOTFOUND",
+		rpcCode: response.RPCCodeNotFound, msg: fmt.Sprintf("Hash %s has not been found", hash)}
+}
+
+func hashWrong(hash string) error {
+	return &apiError{status: http.StatusBadRequest, code: "E_HASHWRONG",
+		rpcCode: response.RPCCodeParamsInvalid, msg: fmt.Sprintf("Hash %s is incorrect", hash)}
+}
+
+func invalidWallet(wallet string) error {
+	return &apiError{status: http.StatusBadRequest, code: "E_INVALIDWALLET",
+		rpcCode: response.RPCCodeInvalidParams, msg: fmt.Sprintf("Wallet %s is not valid", wallet)}
+}
+
+func invalidParams(format string, args ...any) error {
+	return &apiError{status: http.StatusBadRequest, code: "E_UNDEFINEVAL",
+		rpcCode: response.RPCCodeInvalidParams, msg: fmt.Sprintf(format, args...)}
+}
+
+func asAPIError(err error) *apiError {
+	if e, ok := err.(*apiError); ok {
+		return e
+	}
+	return &apiError{status: http.StatusInternalServerError, code: "E_SERVER",
+		rpcCode: response.RPCCodeInternalError, msg: err.Error()}
+}
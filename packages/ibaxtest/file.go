@@ -0,0 +1,105 @@
+package ibaxtest
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/converter"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// file is a binary response of the node, it is written as is instead of JSON
+type file struct {
+	name     string
+	mimeType string
+	data     []byte
+}
+
+func (f *file) write(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", f.mimeType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(f.data)))
+	if f.name != "" {
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, f.name))
+	} else {
+		w.Header().Set("Content-Disposition", "attachment")
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(f.data)
+}
+
+// AddBinary adds a file served by binaryVerify, and returns its id
+func (n *Node) AddBinary(name, mimeType string, data []byte) int64 {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.binaries = append(n.binaries, &file{name: name, mimeType: mimeType, data: data})
+	return int64(len(n.binaries))
+}
+
+// SetAvatar sets the binary served by getAvatar for account in ecosystem
+func (n *Node) SetAvatar(ecosystem int64, account string, binaryID int64) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.avatars[wallet{ecosystem: ecosystem, keyID: converter.StringToAddress(account)}] = binaryID
+}
+
+// FileHash returns the hex hash of data accepted by dataVerify and binaryVerify
+func FileHash(data []byte) string {
+	return hex.EncodeToString(provider.Hash(data))
+}
+
+// compareHash reports whether hash is the md5 or the node hash of data like the node
+func compareHash(data []byte, hash string) bool {
+	hash = strings.ToLower(hash)
+	switch len(hash) {
+	case 32:
+		sum := md5.Sum(data)
+		return hex.EncodeToString(sum[:]) == hash
+	case 64:
+		return FileHash(data) == hash
+	}
+	return false
+}
+
+// dataVerify returns the column of a table row if its hash matches
+func (n *Node) dataVerify(table string, id int64, column, hash string) (*file, error) {
+	row, err := n.row(table, id, column)
+	if err != nil {
+		return nil, err
+	}
+	data, ok := row.Value[column]
+	if !ok {
+		return nil, notFound("Column %s has not been found", column)
+	}
+	if !compareHash([]byte(data), hash) {
+		return nil, hashWrong(hash)
+	}
+	return &file{mimeType: "application/octet-stream", data: []byte(data)}, nil
+}
+
+// binaryVerify returns the binary of id if its hash matches
+func (n *Node) binaryVerify(id int64, hash string) (*file, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if id < 1 || id > int64(len(n.binaries)) {
+		return nil, notFound("Binary %d has not been found", id)
+	}
+	f := n.binaries[id-1]
+	if !compareHash(f.data, hash) {
+		return nil, hashWrong(hash)
+	}
+	return f, nil
+}
+
+// avatar returns the avatar binary of account in ecosystem
+func (n *Node) avatar(account string, ecosystem int64) (*file, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	id, ok := n.avatars[wallet{ecosystem: ecosystem, keyID: converter.StringToAddress(account)}]
+	if !ok || id < 1 || id > int64(len(n.binaries)) {
+		return nil, notFound("Avatar of %s has not been found", account)
+	}
+	f := n.binaries[id-1]
+	return &file{mimeType: f.mimeType, data: f.data}, nil
+}
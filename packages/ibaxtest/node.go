@@ -0,0 +1,248 @@
+package ibaxtest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/IBAX-io/go-ibax-sdk/config"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/converter"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/cryptoer"
+	"github.com/IBAX-io/go-ibax-sdk/packages/response"
+	"github.com/shopspring/decimal"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// NetworkID of the node
+	NetworkID = 1
+	// ApiPath of the RESTful api, the JSON-RPC api is served at the root path
+	ApiPath = "/api/v2/"
+	// Cryptoer and Hasher reported by getuid
+	Cryptoer = "ECC_Secp256k1"
+	Hasher   = "KECCAK256"
+	// Version reported by the node
+	Version = "1.4.2 ibaxtest"
+	// TokenSymbol and Digits of the tokens of every ecosystem
+	TokenSymbol = "IBXC"
+	Digits      = 12
+	// TokenExpire is the lifetime of a login token
+	TokenExpire = 8 * time.Hour
+)
+
+// provider holds the algorithms of Cryptoer and Hasher, the process-global ones of go-ibax are not used
+var provider, _ = cryptoer.New(Cryptoer, Hasher)
+
+// wallet is an account of an ecosystem
+type wallet struct {
+	ecosystem int64
+	keyID     int64
+}
+
+// session of a token, keyID is set by the login
+type session struct {
+	uid       string
+	keyID     int64
+	ecosystem int64
+	roleID    int64
+	expire    time.Time
+}
+
+// Node is an in-process fake IBAX node for integration tests. It serves the RESTful api at URL+ApiPath
+// and the JSON-RPC api at URL with in-memory state: the transactions sent are decoded, their signatures
+// are verified, the token transfers and UTXO moves are applied, and a block is minted for every sendTx
+type Node struct {
+	*httptest.Server
+
+	lock        sync.Mutex
+	sessions    map[string]*session // by token
+	members     map[wallet]bool
+	memberNames map[wallet]string
+	roles       map[wallet][]response.RoleInfo
+	accounts    map[wallet]decimal.Decimal // contract account balances
+	utxos       map[wallet]decimal.Decimal // UTXO balances
+	ecosystems  map[int64]string
+	params      map[paramKey]string
+	contracts   []*Contract
+	tables      map[string][]map[string]string
+	binaries    []*file
+	avatars     map[wallet]int64 // binary ids
+	blocks      []*block
+	txs         map[string]*txRecord // by hex hash
+	rpcDisabled bool
+	tokenExpire time.Duration // lifetime of the login tokens
+}
+
+// NewNode starts a node with the ecosystem 1 and the TokensSend contract, call Close to stop it
+func NewNode() *Node {
+	n := &Node{
+		sessions:    make(map[string]*session),
+		members:     make(map[wallet]bool),
+		memberNames: make(map[wallet]string),
+		roles:       make(map[wallet][]response.RoleInfo),
+		accounts:    make(map[wallet]decimal.Decimal),
+		utxos:       make(map[wallet]decimal.Decimal),
+		ecosystems:  map[int64]string{1: "platform ecosystem"},
+		params:      make(map[paramKey]string),
+		tables:      make(map[string][]map[string]string),
+		avatars:     make(map[wallet]int64),
+		txs:         make(map[string]*txRecord),
+		tokenExpire: TokenExpire,
+	}
+	n.AddContract(TokensSend())
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(ApiPath, n.serveREST)
+	mux.HandleFunc("/", n.serveRPC)
+	n.Server = httptest.NewServer(mux)
+	return n
+}
+
+// Config returns a client config of the node for privateKey, the JSON-RPC backend is used if enableRpc
+func (n *Node) Config(privateKey string, enableRpc bool) config.Config {
+	return config.Config{
+		PrivateKey: privateKey,
+		Ecosystem:  1,
+		ApiAddress: n.URL,
+		ApiPath:    ApiPath,
+		JwtPrefix:  "Bearer ",
+		Cryptoer:   Cryptoer,
+		Hasher:     Hasher,
+		EnableRpc:  enableRpc,
+	}
+}
+
+// Crypto returns the algorithms of Cryptoer and Hasher used by the node
+func (n *Node) Crypto() cryptoer.Provider {
+	return provider
+}
+
+// AddEcosystem adds or renames an ecosystem
+func (n *Node) AddEcosystem(id int64, name string) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.ecosystems[id] = name
+}
+
+// SetBalance sets the contract account balance of account in ecosystem, in the smallest unit
+func (n *Node) SetBalance(ecosystem int64, account string, amount string) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	w := wallet{ecosystem: ecosystem, keyID: converter.StringToAddress(account)}
+	n.accounts[w] = decimal.RequireFromString(amount)
+	n.members[w] = true
+}
+
+// SetUtxo sets the UTXO balance of account in ecosystem, in the smallest unit
+func (n *Node) SetUtxo(ecosystem int64, account string, amount string) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	w := wallet{ecosystem: ecosystem, keyID: converter.StringToAddress(account)}
+	n.utxos[w] = decimal.RequireFromString(amount)
+	n.members[w] = true
+}
+
+// SetRole adds account to the role roleID named name in ecosystem, the role is listed by keyinfo and may be
+// logged in to
+func (n *Node) SetRole(ecosystem int64, account string, roleID int64, name string) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	w := wallet{ecosystem: ecosystem, keyID: converter.StringToAddress(account)}
+	n.roles[w] = append(n.roles[w], response.RoleInfo{ID: strconv.FormatInt(roleID, 10), Name: name})
+	n.members[w] = true
+}
+
+// Balance returns the contract account and UTXO balances of account in ecosystem
+func (n *Node) Balance(ecosystem int64, account string) (amount, utxo string) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	w := wallet{ecosystem: ecosystem, keyID: converter.StringToAddress(account)}
+	return n.accounts[w].String(), n.utxos[w].String()
+}
+
+// SetTable replaces the rows of a table served by getList and getRow, every row should have an "id" column
+func (n *Node) SetTable(name string, rows ...map[string]string) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.tables[name] = rows
+}
+
+// MaxBlockID returns the id of the last minted block
+func (n *Node) MaxBlockID() int64 {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return int64(len(n.blocks))
+}
+
+// DisableRPC stops serving JSON-RPC like a node with it disabled, the root path answers 404
+func (n *Node) DisableRPC() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.rpcDisabled = true
+}
+
+// SetTokenExpire sets the lifetime of the login tokens issued from now on, TokenExpire by default
+func (n *Node) SetTokenExpire(d time.Duration) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.tokenExpire = d
+}
+
+// RevokeTokens invalidates all the tokens, the requests needing a login fail as unauthorized
+func (n *Node) RevokeTokens() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.sessions = make(map[string]*session)
+}
+
+// Role returns the role the token is logged in with, false if the token is not logged in
+func (n *Node) Role(token string) (int64, bool) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	s, err := n.loggedIn(token)
+	if err != nil {
+		return 0, false
+	}
+	return s.roleID, true
+}
+
+func randomHex(size int) string {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(buf)
+}
+
+// newSession returns a token of a new uid, the lock is held
+func (n *Node) newSession() (token string, s *session) {
+	token = randomHex(32)
+	s = &session{uid: randomHex(16)}
+	n.sessions[token] = s
+	return
+}
+
+// session returns the session of the Authorization header, the lock is held
+func (n *Node) session(authorization string) *session {
+	token := strings.TrimSpace(strings.TrimPrefix(authorization, "Bearer"))
+	return n.sessions[token]
+}
+
+// authorize returns the session of a logged in and unexpired token
+func (n *Node) authorize(authorization string) (*session, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.loggedIn(authorization)
+}
+
+// loggedIn returns the session of a logged in and unexpired token, the lock is held
+func (n *Node) loggedIn(authorization string) (*session, error) {
+	s := n.session(authorization)
+	if s == nil || s.keyID == 0 || time.Now().After(s.expire) {
+		return nil, errUnauthorized
+	}
+	return s, nil
+}
@@ -0,0 +1,199 @@
+package ibaxtest
+
+import (
+	"bytes"
+	"crypto/md5"
+	"errors"
+	"fmt"
+	"github.com/IBAX-io/go-ibax-sdk/packages/client"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/converter"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/transaction"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/types"
+	"github.com/IBAX-io/go-ibax-sdk/packages/request"
+	"github.com/IBAX-io/go-ibax-sdk/packages/response"
+	"github.com/IBAX-io/go-ibax-sdk/packages/transport"
+	"github.com/IBAX-io/go-ibax/packages/common/crypto"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestNode(t *testing.T) {
+	node := NewNode()
+	defer node.Close()
+	node.SetTable("members", map[string]string{"id": "1", "member_name": "founder"},
+		map[string]string{"id": "2", "member_name": "guest"})
+
+	for _, enableRpc := range []bool{false, true} {
+		t.Run(fmt.Sprintf("rpc=%v", enableRpc), func(t *testing.T) {
+			key, _, err := crypto.GenHexKeys()
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, pub, err := node.Crypto().GenKeyPair()
+			if err != nil {
+				t.Fatal(err)
+			}
+			recipient := converter.AddressToString(node.Crypto().Address(pub))
+
+			c, err := client.NewClientWithOptions(node.Config(key, enableRpc), transport.WithReauth())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = c.AutoLogin(); err != nil {
+				t.Fatalf("auto login failed: %s", err)
+			}
+			account := c.GetConfig().Account
+			node.SetBalance(1, account, "1000")
+
+			form := url.Values{"Recipient": {recipient}, "Amount": {"300"}}
+			result, err := c.AutoCallContract("@1TokensSend", &form, "")
+			if err != nil {
+				t.Fatalf("call contract failed: %s", err)
+			}
+			if result.BlockId != node.MaxBlockID() || result.Failure() != nil {
+				t.Fatalf("unexpected result %+v", result)
+			}
+			if amount, _ := node.Balance(1, recipient); amount != "300" {
+				t.Errorf("recipient balance %s, want 300", amount)
+			}
+
+			form.Set("Amount", "5000")
+			result, err = c.AutoCallContract("@1TokensSend", &form, "")
+			if err != nil {
+				t.Fatalf("call contract failed: %s", err)
+			}
+			if !errors.Is(result.Failure(), response.ErrContractPenalty) || result.BlockId == 0 {
+				t.Errorf("expected a penalty, got %+v", result)
+			}
+
+			result, err = c.AutoCallUtxo(request.TypeContractToUTXO, url.Values{"amount": {"200"}}, "")
+			if err != nil || result.Failure() != nil {
+				t.Fatalf("contract to utxo failed: %v %+v", err, result)
+			}
+			result, err = c.AutoCallUtxo(request.TypeTransfer, url.Values{"recipient": {recipient}, "amount": {"50"}}, "")
+			if err != nil || result.Failure() != nil {
+				t.Fatalf("utxo transfer failed: %v %+v", err, result)
+			}
+			balance, err := c.Balance(account, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if balance.Amount != "500" || balance.Utxo != "150" || balance.Total != "650" {
+				t.Errorf("unexpected balance %+v", balance)
+			}
+			if _, utxo := node.Balance(1, recipient); utxo != "50" {
+				t.Errorf("recipient utxo %s, want 50", utxo)
+			}
+
+			// the node rejects a transaction of another network
+			cnf := c.GetConfig()
+			privateKey := converter.HexToBin(cnf.PrivateKey)
+			data, hash, err := transaction.NewTransaction(node.Crypto(), types.SmartTransaction{
+				Header: &types.Header{ID: 1, Time: time.Now().Unix(), EcosystemID: 1,
+					KeyID: cnf.KeyId, NetworkID: NetworkID + 1},
+				Params: map[string]any{"Recipient": recipient, "Amount": "1"},
+			}, privateKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err = c.SendTx(map[string][]byte{fmt.Sprintf("%x", hash): data}); err != nil {
+				t.Fatal(err)
+			}
+			status, err := c.TxStatus(fmt.Sprintf("%x", hash), 3, time.Millisecond)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if status.BlockId != 0 || status.Failure() == nil {
+				t.Errorf("expected a rejected transaction, got %+v", status)
+			}
+
+			node.RevokeTokens()
+			list, err := c.GetList(request.GetList{ListForm: request.ListForm{Name: "members", RowForm: request.RowForm{Columns: "member_name"}}})
+			if err != nil {
+				t.Fatalf("get list failed: %s", err)
+			}
+			if list.Count != 2 || list.List[1]["member_name"] != "guest" {
+				t.Errorf("unexpected list %+v", list)
+			}
+			row, err := c.GetRow("members", 1, "", "")
+			if err != nil || row.Value["member_name"] != "founder" {
+				t.Errorf("unexpected row %+v %v", row, err)
+			}
+			_, err = c.GetRow("members", 3, "", "")
+			if !errors.Is(err, response.ErrNotFound) {
+				t.Errorf("expected not found, got %v", err)
+			}
+
+			blocks, err := c.DetailedBlocks(result.BlockId, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if b := (*blocks)[result.BlockId]; len(b.Transactions) != 1 || b.Transactions[0].Type != types.UtxoTxType {
+				t.Errorf("unexpected block %+v", b)
+			}
+		})
+	}
+}
+
+func TestNode_Files(t *testing.T) {
+	node := NewNode()
+	defer node.Close()
+	image := bytes.Repeat([]byte{0x89, 'P', 'N', 'G'}, 1<<16)
+	id := node.AddBinary("avatar.png", "image/png", image)
+	node.SetTable("documents", map[string]string{"id": "1", "body": "hello"})
+
+	for _, enableRpc := range []bool{false, true} {
+		t.Run(fmt.Sprintf("rpc=%v", enableRpc), func(t *testing.T) {
+			key, _, err := crypto.GenHexKeys()
+			if err != nil {
+				t.Fatal(err)
+			}
+			c, err := client.NewClientWithOptions(node.Config(key, enableRpc))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = c.AutoLogin(); err != nil {
+				t.Fatal(err)
+			}
+			node.SetAvatar(1, c.GetConfig().Account, id)
+
+			var buf bytes.Buffer
+			stream := response.NewFileStream(&buf, nil)
+			if err = c.BinaryVerifyTo(stream, id, FileHash(image)); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(buf.Bytes(), image) || stream.ContentType != "image/png" ||
+				stream.ContentLength != int64(len(image)) || stream.Written != int64(len(image)) {
+				t.Errorf("unexpected binary %d bytes %+v", buf.Len(), stream)
+			}
+
+			buf.Reset()
+			stream = response.NewFileStream(&buf, md5.New())
+			if err = c.GetAvatarTo(stream, c.GetConfig().Account, 1); err != nil {
+				t.Fatal(err)
+			}
+			if sum := md5.Sum(image); stream.Sum() != fmt.Sprintf("%x", sum) {
+				t.Errorf("unexpected avatar hash %s", stream.Sum())
+			}
+
+			buf.Reset()
+			sum := md5.Sum([]byte("hello"))
+			if err = c.DataVerifyTo(response.NewFileStream(&buf, nil), "documents", 1, "body", fmt.Sprintf("%x", sum)); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != "hello" {
+				t.Errorf("unexpected data %q", buf.String())
+			}
+
+			buf.Reset()
+			err = c.BinaryVerifyTo(response.NewFileStream(&buf, nil), id, FileHash([]byte("other")))
+			if !errors.Is(err, response.ErrInvalidParams) || buf.Len() != 0 {
+				t.Errorf("expected a wrong hash error, got %v", err)
+			}
+			if err = c.BinaryVerifyTo(response.NewFileStream(&buf, nil), id+1, FileHash(image)); !errors.Is(err, response.ErrNotFound) {
+				t.Errorf("expected not found, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package ibaxtest
+
+import (
+	"fmt"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/converter"
+	"github.com/IBAX-io/go-ibax-sdk/packages/response"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// paramKey is a parameter of an ecosystem, or of an application of the ecosystem if app is not 0
+type paramKey struct {
+	ecosystem int64
+	app       int64
+	name      string
+}
+
+// SetEcosystemParam sets a parameter of ecosystem served by ecosystemparam and getEcosystemParams
+func (n *Node) SetEcosystemParam(ecosystem int64, name, value string) {
+	n.SetAppParam(ecosystem, 0, name, value)
+}
+
+// SetAppParam sets a parameter of the application appID of ecosystem served by appparam and appParams
+func (n *Node) SetAppParam(ecosystem, appID int64, name, value string) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.params[paramKey{ecosystem: ecosystem, app: appID, name: name}] = value
+}
+
+// SetMember sets the member name of account in ecosystem served by member and getMember
+func (n *Node) SetMember(ecosystem int64, account, name string) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	w := wallet{ecosystem: ecosystem, keyID: converter.StringToAddress(account)}
+	n.members[w] = true
+	n.memberNames[w] = name
+}
+
+// paramList returns the parameters of the comma separated names sorted by name, all of them if names is empty
+func (n *Node) paramList(ecosystem, app int64, names string) []response.ParamResult {
+	if ecosystem == 0 {
+		ecosystem = 1
+	}
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	filter := make(map[string]bool)
+	for _, v := range strings.Split(names, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			filter[v] = true
+		}
+	}
+	list := []response.ParamResult{}
+	for k, v := range n.params {
+		if k.ecosystem != ecosystem || k.app != app || len(filter) > 0 && !filter[k.name] {
+			continue
+		}
+		list = append(list, response.ParamResult{Name: k.name, Value: v, Conditions: "ContractConditions(\"@1DeveloperCondition\")"})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	for k := range list {
+		list[k].ID = strconv.Itoa(k + 1)
+	}
+	return list
+}
+
+// param returns a parameter of ecosystem, or of the application app of ecosystem
+func (n *Node) param(ecosystem, app int64, name string) (*response.ParamResult, error) {
+	list := n.paramList(ecosystem, app, name)
+	if len(list) == 0 {
+		return nil, notFound("Parameter %s has not been found", name)
+	}
+	return &list[0], nil
+}
+
+func (n *Node) ecosystemInfo(id int64) (*response.EcosystemInfo, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	name, ok := n.ecosystems[id]
+	if !ok {
+		return nil, notFound("Ecosystem %d has not been found", id)
+	}
+	return &response.EcosystemInfo{Id: id, Name: name, Digits: Digits, TokenSymbol: TokenSymbol}, nil
+}
+
+func (n *Node) memberInfo(account string, ecosystem int64) (*response.MemberInfo, error) {
+	keyID := converter.StringToAddress(account)
+	if keyID == 0 {
+		return nil, invalidWallet(account)
+	}
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	w := wallet{ecosystem: ecosystem, keyID: keyID}
+	if !n.members[w] {
+		return nil, notFound("Member %s has not been found", account)
+	}
+	return &response.MemberInfo{ID: keyID, MemberName: n.memberNames[w]}, nil
+}
+
+// authStatus reports whether the token is logged in and when it expires
+func (n *Node) authStatus(authorization string) *response.AuthStatusResponse {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	s, err := n.loggedIn(authorization)
+	if err != nil {
+		return &response.AuthStatusResponse{}
+	}
+	return &response.AuthStatusResponse{IsActive: true, ExpiresAt: s.expire.Unix()}
+}
+
+// listWhere returns the rows of a table matching where, the column values of where are compared as strings
+func (n *Node) listWhere(name string, where map[string]any, limit, offset int, columns string) (*response.ListResult, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	rows, ok := n.tables[name]
+	if !ok {
+		return nil, notFound("Table %s has not been found", name)
+	}
+	var matched []map[string]string
+	for _, row := range rows {
+		if matchRow(row, where) {
+			matched = append(matched, row)
+		}
+	}
+	return page(matched, limit, offset, columns), nil
+}
+
+func matchRow(row map[string]string, where map[string]any) bool {
+	for k, v := range where {
+		if row[k] != fmt.Sprint(v) {
+			return false
+		}
+	}
+	return true
+}
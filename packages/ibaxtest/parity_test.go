@@ -0,0 +1,140 @@
+package ibaxtest
+
+import (
+	"encoding/hex"
+	"errors"
+	"github.com/IBAX-io/go-ibax-sdk/packages/client"
+	"github.com/IBAX-io/go-ibax-sdk/packages/modus"
+	"github.com/IBAX-io/go-ibax-sdk/packages/request"
+	"github.com/IBAX-io/go-ibax-sdk/packages/response"
+	"github.com/IBAX-io/go-ibax/packages/common/crypto"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// TestParity runs the same queries against the RESTful and the JSON-RPC clients of a node,
+// both must return the wanted result, or an error matching the wanted one
+func TestParity(t *testing.T) {
+	node := NewNode()
+	defer node.Close()
+	node.AddEcosystem(2, "second ecosystem")
+	node.SetTable("members", map[string]string{"id": "1", "member_name": "founder"},
+		map[string]string{"id": "2", "member_name": "guest"}, map[string]string{"id": "3", "member_name": "guest"})
+	node.SetEcosystemParam(1, "founder_account", "1000")
+	node.SetEcosystemParam(1, "max_sum", "1000000")
+	node.SetAppParam(1, 1, "voting_period", "7")
+
+	key, _, err := crypto.GenHexKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	clients := make(map[string]modus.Client)
+	for name, enableRpc := range map[string]bool{"rest": false, "rpc": true} {
+		c, err := client.NewClientWithOptions(node.Config(key, enableRpc))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = c.AutoLogin(); err != nil {
+			t.Fatalf("%s login failed: %s", name, err)
+		}
+		clients[name] = c
+	}
+	account := clients["rest"].GetConfig().Account
+	node.SetBalance(1, account, "1000")
+	node.SetMember(1, account, "tester")
+	form := url.Values{"Recipient": {account}, "Amount": {"1"}}
+	if _, err = clients["rest"].AutoCallContract("@1TokensSend", &form, ""); err != nil {
+		t.Fatal(err)
+	}
+	blockHash := hex.EncodeToString(node.blocks[0].hash)
+
+	guests := request.GetList{Where: map[string]any{"member_name": "guest"}}
+	guests.Name, guests.Limit, guests.Columns = "members", 10, "member_name"
+	guestList := &response.ListResult{Count: 2, List: []map[string]string{
+		{"id": "2", "member_name": "guest"}, {"id": "3", "member_name": "guest"}}}
+
+	cases := []struct {
+		name string
+		call func(c modus.Client) (any, error)
+		want any
+		err  error
+	}{
+		{"GetBalance", func(c modus.Client) (any, error) {
+			b, err := c.GetBalance(account)
+			return b.Amount, err
+		}, "1000", nil},
+		{"BlocksCount", func(c modus.Client) (any, error) { return c.BlocksCount() }, int64(1), nil},
+		{"BlockTxCountById", func(c modus.Client) (any, error) {
+			return c.BlockTxCount(request.BlockIdOrHash{Id: 1})
+		}, int64(1), nil},
+		{"BlockTxCountByHash", func(c modus.Client) (any, error) {
+			return c.BlockTxCount(request.BlockIdOrHash{Hash: blockHash})
+		}, int64(1), nil},
+		{"DetailedBlockByHash", func(c modus.Client) (any, error) {
+			b, err := c.DetailedBlock(request.BlockIdOrHash{Hash: blockHash})
+			return b.Header.BlockID, err
+		}, int64(1), nil},
+		{"DetailedBlockById", func(c modus.Client) (any, error) {
+			b, err := c.DetailedBlock(request.BlockIdOrHash{Id: 1})
+			return b.Hash, err
+		}, blockHash, nil},
+		{"DetailedBlockMissing", func(c modus.Client) (any, error) {
+			return c.DetailedBlock(request.BlockIdOrHash{Id: 2})
+		}, nil, response.ErrNotFound},
+		{"EcosystemName", func(c modus.Client) (any, error) { return c.EcosystemName(2) },
+			&response.EcosystemNameResult{EcosystemName: "second ecosystem"}, nil},
+		{"EcosystemInfo", func(c modus.Client) (any, error) {
+			info, err := c.EcosystemInfo(2)
+			return info.Name, err
+		}, "second ecosystem", nil},
+		{"EcosystemParam", func(c modus.Client) (any, error) {
+			p, err := c.EcosystemParam(1, "max_sum")
+			return p.Value, err
+		}, "1000000", nil},
+		{"EcosystemParamMissing", func(c modus.Client) (any, error) {
+			return c.EcosystemParam(1, "missing")
+		}, nil, response.ErrNotFound},
+		{"AppParam", func(c modus.Client) (any, error) {
+			p, err := c.AppParam(1, "voting_period", 1)
+			return p.Value, err
+		}, "7", nil},
+		{"GetMemberInfo", func(c modus.Client) (any, error) {
+			m, err := c.GetMemberInfo(account, 1)
+			return m.MemberName, err
+		}, "tester", nil},
+		{"GetRowExtend", func(c modus.Client) (any, error) {
+			return c.GetRowExtend("members", "member_name", "founder", "")
+		}, &response.RowResult{Value: map[string]string{"id": "1", "member_name": "founder"}}, nil},
+		{"GetRowExtendMissing", func(c modus.Client) (any, error) {
+			return c.GetRowExtend("members", "member_name", "nobody", "")
+		}, nil, response.ErrNotFound},
+		{"GetListWhere", func(c modus.Client) (any, error) { return c.GetListWhere(guests) }, guestList, nil},
+		{"GetNodeListWhere", func(c modus.Client) (any, error) { return c.GetNodeListWhere(guests) }, guestList, nil},
+		{"GetListWhereInvalid", func(c modus.Client) (any, error) {
+			return c.GetListWhere(request.GetList{ListForm: request.ListForm{Name: "members"}})
+		}, nil, response.ErrInvalidParams},
+		{"GetAuthStatus", func(c modus.Client) (any, error) {
+			status, err := c.GetAuthStatus()
+			return status.IsActive, err
+		}, true, nil},
+	}
+	for _, v := range cases {
+		t.Run(v.name, func(t *testing.T) {
+			for name, c := range clients {
+				got, err := v.call(c)
+				if v.err != nil {
+					if !errors.Is(err, v.err) {
+						t.Errorf("%s: want %v, got %v", name, v.err, err)
+					}
+					continue
+				}
+				if err != nil {
+					t.Errorf("%s: %v", name, err)
+				} else if !reflect.DeepEqual(got, v.want) {
+					t.Errorf("%s: want %+v, got %+v", name, v.want, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package ibaxtest
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"github.com/IBAX-io/go-ibax-sdk/packages/response"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type restTxInfo struct {
+	BlockID string           `json:"blockid"`
+	Confirm int              `json:"confirm"`
+	Data    *response.TxInfo `json:"data,omitempty"`
+}
+
+// serveREST serves the RESTful api at ApiPath
+func (n *Node) serveREST(w http.ResponseWriter, r *http.Request) {
+	name, arg, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, ApiPath), "/")
+	result, err := n.rest(r, name, arg)
+	if err != nil {
+		e := asAPIError(err)
+		writeJSON(w, e.status, map[string]string{"error": e.code, "msg": e.msg})
+		return
+	}
+	if f, ok := result.(*file); ok {
+		f.write(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (n *Node) rest(r *http.Request, name, arg string) (any, error) {
+	authorization := r.Header.Get("Authorization")
+	switch name {
+	case "getuid":
+		return n.getUID(authorization), nil
+	case "login":
+		publicKey, _ := hex.DecodeString(r.FormValue("pubkey"))
+		signature, _ := hex.DecodeString(r.FormValue("signature"))
+		return n.login(authorization, publicKey, signature, formInt(r, "ecosystem"), formInt(r, "role_id"))
+	case "keyinfo":
+		return n.keyInfo(arg)
+	case "balance":
+		return n.balance(arg, formInt(r, "ecosystem"))
+	case "maxblockid":
+		return map[string]int64{"max_block_id": n.MaxBlockID()}, nil
+	case "block":
+		n.lock.Lock()
+		defer n.lock.Unlock()
+		b, err := n.block(parseInt(arg))
+		if err != nil {
+			return nil, err
+		}
+		return &response.BlockInfoHexResult{Hash: b.hash, Time: b.time, Tx: int32(len(b.txs))}, nil
+	case "detailed_blocks":
+		return n.detailedBlocks(formInt(r, "block_id"), formInt(r, "count")), nil
+	case "version":
+		return Version, nil
+	case "txinfo":
+		return n.restTxInfo(arg, r.FormValue("contractinfo") == "1"), nil
+	case "txinfomultiple":
+		results := make(map[string]*restTxInfo)
+		for _, hash := range strings.Split(r.FormValue("data"), ",") {
+			results[hash] = n.restTxInfo(hash, r.FormValue("contractinfo") == "1")
+		}
+		return map[string]any{"results": results}, nil
+	case "data":
+		// data/{table}/{id}/{column}/{hash} or data/{id}/data/{hash} of a binary
+		parts := strings.Split(arg, "/")
+		if len(parts) == 3 && parts[1] == "data" {
+			return n.binaryVerify(parseInt(parts[0]), parts[2])
+		}
+		if len(parts) == 4 {
+			return n.dataVerify(parts[0], parseInt(parts[1]), parts[2], parts[3])
+		}
+	case "avatar":
+		ecosystem, account, _ := strings.Cut(arg, "/")
+		return n.avatar(account, parseInt(ecosystem))
+	case "ecosystemname":
+		info, err := n.ecosystemInfo(formInt(r, "id"))
+		if err != nil {
+			return nil, err
+		}
+		return &response.EcosystemNameResult{EcosystemName: info.Name}, nil
+	case "member":
+		ecosystem, account, _ := strings.Cut(arg, "/")
+		return n.memberInfo(account, parseInt(ecosystem))
+	case "metrics":
+		if arg == "blocks" {
+			return map[string]int64{"count": n.MaxBlockID()}, nil
+		}
+	case "auth":
+		if arg == "status" {
+			return n.authStatus(authorization), nil
+		}
+	}
+
+	s, err := n.authorize(authorization)
+	if err != nil {
+		return nil, err
+	}
+	switch name {
+	case "contract":
+		return n.contractInfo(arg, s.ecosystem)
+	case "contracts":
+		return n.contractList(int(formInt(r, "limit")), int(formInt(r, "offset"))), nil
+	case "list":
+		return n.listWhere(arg, nil, int(formInt(r, "limit")), int(formInt(r, "offset")), r.FormValue("columns"))
+	case "listWhere", "nodelistWhere":
+		var where map[string]any
+		if data := r.FormValue("where"); data != "" {
+			if err := json.Unmarshal([]byte(data), &where); err != nil {
+				return nil, invalidParams("where is invalid:%s", err.Error())
+			}
+		}
+		return n.listWhere(arg, where, int(formInt(r, "limit")), int(formInt(r, "offset")), r.FormValue("columns"))
+	case "row":
+		// row/{table}/{id} or row/{table}/{column}/{value}
+		parts := strings.Split(arg, "/")
+		if len(parts) == 3 {
+			list, err := n.listWhere(parts[0], map[string]any{parts[1]: parts[2]}, 1, 0, r.FormValue("columns"))
+			if err != nil {
+				return nil, err
+			}
+			if len(list.List) == 0 {
+				return nil, notFound("Row %s=%s of %s has not been found", parts[1], parts[2], parts[0])
+			}
+			return &response.RowResult{Value: list.List[0]}, nil
+		}
+		table, id, _ := strings.Cut(arg, "/")
+		return n.row(table, parseInt(id), r.FormValue("columns"))
+	case "ecosystemparam":
+		return n.param(formEcosystem(r, s), 0, arg)
+	case "ecosystemparams":
+		return &response.ParamsResult{List: n.paramList(formEcosystem(r, s), 0, r.FormValue("names"))}, nil
+	case "appparam":
+		app, name, _ := strings.Cut(arg, "/")
+		return n.param(formEcosystem(r, s), parseInt(app), name)
+	case "appparams":
+		return &response.AppParamsResult{App: arg, List: n.paramList(formEcosystem(r, s), parseInt(arg), r.FormValue("names"))}, nil
+	case "sendTx":
+		txs, err := multipartFiles(r)
+		if err != nil {
+			return nil, err
+		}
+		hashes, err := n.sendTx(txs)
+		if err != nil {
+			return nil, err
+		}
+		return &response.SendTxResult{Hashes: hashes}, nil
+	case "txstatus":
+		var req struct {
+			Hashes []string `json:"hashes"`
+		}
+		if err := json.Unmarshal([]byte(r.FormValue("data")), &req); err != nil {
+			return nil, invalidParams("data is invalid:%s", err.Error())
+		}
+		n.lock.Lock()
+		defer n.lock.Unlock()
+		results, err := n.txStatus(req.Hashes)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"results": results}, nil
+	}
+	return nil, notFound("%s has not been found", r.URL.Path)
+}
+
+func (n *Node) restTxInfo(hash string, contractInfo bool) *restTxInfo {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	blockID, confirm, info := n.txInfo(hash, contractInfo)
+	return &restTxInfo{BlockID: strconv.FormatInt(blockID, 10), Confirm: confirm, Data: info}
+}
+
+// multipartFiles returns the data of the files of a multipart request by form name
+func multipartFiles(r *http.Request) (map[string][]byte, error) {
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		return nil, invalidParams("multipart form is invalid:%s", err.Error())
+	}
+	txs := make(map[string][]byte)
+	for name, files := range r.MultipartForm.File {
+		for _, fh := range files {
+			f, err := fh.Open()
+			if err != nil {
+				return nil, err
+			}
+			data, err := io.ReadAll(f)
+			f.Close()
+			if err != nil {
+				return nil, err
+			}
+			txs[name] = data
+		}
+	}
+	return txs, nil
+}
+
+// formEcosystem returns the ecosystem of the request, the one of the session if it is not set
+func formEcosystem(r *http.Request, s *session) int64 {
+	if ecosystem := formInt(r, "ecosystem"); ecosystem != 0 {
+		return ecosystem
+	}
+	return s.ecosystem
+}
+
+func formInt(r *http.Request, key string) int64 {
+	return parseInt(r.FormValue(key))
+}
+
+func parseInt(s string) int64 {
+	v, _ := strconv.ParseInt(s, 10, 64)
+	return v
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,363 @@
+package ibaxtest
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/IBAX-io/go-ibax-sdk/packages/request"
+	"github.com/IBAX-io/go-ibax-sdk/packages/response"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type rpcLoginForm struct {
+	EcosystemID int64  `json:"ecosystem_id"`
+	PublicKey   string `json:"public_key"`
+	Signature   string `json:"signature"`
+	RoleID      int64  `json:"role_id"`
+}
+
+// rpcMethods of the ibax namespace served by the node, listed by rpc.modules
+var rpcMethods = []string{"getUid", "login", "getKeyInfo", "getBalance", "maxBlockId", "getBlockInfo",
+	"detailedBlocks", "detailedBlock", "getTransactionCount", "ecosystemInfo", "getMember", "getAuthStatus",
+	"getVersion", "txInfo", "txInfoMultiple", "dataVerify", "binaryVerify", "getAvatar", "getContractInfo",
+	"getContracts", "getList", "getEcosystemParams", "appParams", "getRow", "sendTx", "txStatus"}
+
+type rpcTxInfo struct {
+	BlockID int64            `json:"blockid"`
+	Confirm int              `json:"confirm"`
+	Data    *response.TxInfo `json:"data,omitempty"`
+}
+
+// serveRPC serves the JSON-RPC api, a request or a batch
+func (n *Node) serveRPC(w http.ResponseWriter, r *http.Request) {
+	n.lock.Lock()
+	disabled := n.rpcDisabled
+	n.lock.Unlock()
+	if disabled {
+		http.NotFound(w, r)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	authorization := r.Header.Get("Authorization")
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		var reqs []request.Request
+		if err := json.Unmarshal(body, &reqs); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		resps := make(response.BatchResponse, len(reqs))
+		for k, req := range reqs {
+			resps[k] = n.rpcResponse(authorization, req)
+		}
+		writeJSON(w, http.StatusOK, resps)
+		return
+	}
+	var req request.Request
+	if err := json.Unmarshal(body, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	result, err := n.rpc(authorization, rpcMethod(req), req.Params)
+	if f, ok := result.(*file); ok && err == nil {
+		f.write(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, newRPCResponse(req, result, err))
+}
+
+func rpcMethod(req request.Request) string {
+	return strings.TrimPrefix(req.Method, string(request.NamespaceIBAX)+request.NamespaceSeparator)
+}
+
+// rpcResponse handles a request of a batch, the files are not sent in a batch
+func (n *Node) rpcResponse(authorization string, req request.Request) response.Response {
+	result, err := n.rpc(authorization, rpcMethod(req), req.Params)
+	if _, ok := result.(*file); ok {
+		result, err = nil, invalidParams("%s is not supported in a batch", req.Method)
+	}
+	return newRPCResponse(req, result, err)
+}
+
+func newRPCResponse(req request.Request, result any, err error) response.Response {
+	resp := response.Response{JSONRPC: request.JsonRPCVersion, ID: req.ID}
+	if err == nil {
+		resp.Result, err = json.Marshal(result)
+	}
+	if err != nil {
+		e := asAPIError(err)
+		resp.Result = nil
+		resp.Error = &response.RPCError{Code: e.rpcCode, Message: e.msg}
+	}
+	return resp
+}
+
+func (n *Node) rpc(authorization, method string, params request.Params) (any, error) {
+	switch method {
+	case "rpc.modules":
+		modules := []string{"rpc.modules"}
+		for _, v := range rpcMethods {
+			modules = append(modules, string(request.NamespaceIBAX)+request.NamespaceSeparator+v)
+		}
+		return modules, nil
+	case "getUid":
+		return n.getUID(authorization), nil
+	case "login":
+		var form rpcLoginForm
+		if err := decodeParams(params, &form); err != nil {
+			return nil, err
+		}
+		publicKey, _ := hex.DecodeString(form.PublicKey)
+		signature, _ := hex.DecodeString(form.Signature)
+		return n.login(authorization, publicKey, signature, form.EcosystemID, form.RoleID)
+	case "getKeyInfo":
+		var account string
+		if err := decodeParams(params, &account); err != nil {
+			return nil, err
+		}
+		return n.keyInfo(account)
+	case "getBalance":
+		var (
+			account   string
+			ecosystem int64
+		)
+		if err := decodeParams(params, &account, &ecosystem); err != nil {
+			return nil, err
+		}
+		return n.balance(account, ecosystem)
+	case "maxBlockId":
+		return n.MaxBlockID(), nil
+	case "getBlockInfo":
+		var id int64
+		if err := decodeParams(params, &id); err != nil {
+			return nil, err
+		}
+		n.lock.Lock()
+		defer n.lock.Unlock()
+		b, err := n.block(id)
+		if err != nil {
+			return nil, err
+		}
+		return &response.BlockInfoResult{Hash: hex.EncodeToString(b.hash), Time: b.time, Tx: int32(len(b.txs))}, nil
+	case "detailedBlocks":
+		var blockID, count int64
+		if err := decodeParams(params, &blockID, &count); err != nil {
+			return nil, err
+		}
+		return n.detailedBlocks(blockID, count), nil
+	case "detailedBlock":
+		n.lock.Lock()
+		defer n.lock.Unlock()
+		b, err := n.rpcBlock(params)
+		if err != nil {
+			return nil, err
+		}
+		return b.detailed(), nil
+	case "getTransactionCount":
+		n.lock.Lock()
+		defer n.lock.Unlock()
+		b, err := n.rpcBlock(params)
+		if err != nil {
+			return nil, err
+		}
+		return len(b.txs), nil
+	case "ecosystemInfo":
+		var ecosystem int64
+		if err := decodeParams(params, &ecosystem); err != nil {
+			return nil, err
+		}
+		return n.ecosystemInfo(ecosystem)
+	case "getMember":
+		var (
+			account   string
+			ecosystem int64
+		)
+		if err := decodeParams(params, &account, &ecosystem); err != nil {
+			return nil, err
+		}
+		return n.memberInfo(account, ecosystem)
+	case "getAuthStatus":
+		return n.authStatus(authorization), nil
+	case "getVersion":
+		return Version, nil
+	case "txInfo":
+		var (
+			hash         string
+			contractInfo bool
+		)
+		if err := decodeParams(params, &hash, &contractInfo); err != nil {
+			return nil, err
+		}
+		return n.rpcTxInfo(hash, contractInfo), nil
+	case "txInfoMultiple":
+		var (
+			hashes       []string
+			contractInfo bool
+		)
+		if err := decodeParams(params, &hashes, &contractInfo); err != nil {
+			return nil, err
+		}
+		results := make(map[string]*rpcTxInfo)
+		for _, hash := range hashes {
+			results[hash] = n.rpcTxInfo(hash, contractInfo)
+		}
+		return map[string]any{"results": results}, nil
+	case "dataVerify":
+		var (
+			table, column, hash string
+			id                  int64
+		)
+		if err := decodeParams(params, &table, &column, &id, &hash); err != nil {
+			return nil, err
+		}
+		return n.dataVerify(table, id, column, hash)
+	case "binaryVerify":
+		var (
+			id   int64
+			hash string
+		)
+		if err := decodeParams(params, &id, &hash); err != nil {
+			return nil, err
+		}
+		return n.binaryVerify(id, hash)
+	case "getAvatar":
+		var (
+			account   string
+			ecosystem int64
+		)
+		if err := decodeParams(params, &account, &ecosystem); err != nil {
+			return nil, err
+		}
+		return n.avatar(account, ecosystem)
+	}
+
+	s, err := n.authorize(authorization)
+	if err != nil {
+		return nil, err
+	}
+	switch method {
+	case "getContractInfo":
+		var name string
+		if err := decodeParams(params, &name); err != nil {
+			return nil, err
+		}
+		return n.contractInfo(name, s.ecosystem)
+	case "getContracts":
+		var offset, limit int
+		if err := decodeParams(params, &offset, &limit); err != nil {
+			return nil, err
+		}
+		return n.contractList(limit, offset), nil
+	case "getList":
+		var form request.GetList
+		if err := decodeParams(params, &form); err != nil {
+			return nil, err
+		}
+		where, _ := form.Where.(map[string]any)
+		return n.listWhere(form.Name, where, form.Limit, form.Offset, form.Columns)
+	case "getEcosystemParams":
+		var (
+			ecosystem int64
+			names     string
+		)
+		if err := decodeParams(params, &ecosystem, &names); err != nil {
+			return nil, err
+		}
+		if ecosystem == 0 {
+			ecosystem = s.ecosystem
+		}
+		return &response.ParamsResult{List: n.paramList(ecosystem, 0, names)}, nil
+	case "appParams":
+		var (
+			app, ecosystem int64
+			names          string
+		)
+		if err := decodeParams(params, &app, &ecosystem, &names); err != nil {
+			return nil, err
+		}
+		if ecosystem == 0 {
+			ecosystem = s.ecosystem
+		}
+		return map[string]any{"app_id": app, "list": n.paramList(ecosystem, app, names)}, nil
+	case "getRow":
+		var (
+			table   string
+			id      int64
+			columns string
+		)
+		if err := decodeParams(params, &table, &id, &columns); err != nil {
+			return nil, err
+		}
+		return n.row(table, id, columns)
+	case "sendTx":
+		var txs map[string][]byte
+		if err := decodeParams(params, &txs); err != nil {
+			return nil, err
+		}
+		hashes, err := n.sendTx(txs)
+		if err != nil {
+			return nil, err
+		}
+		return &response.SendTxResult{Hashes: hashes}, nil
+	case "txStatus":
+		var hashes string
+		if err := decodeParams(params, &hashes); err != nil {
+			return nil, err
+		}
+		n.lock.Lock()
+		defer n.lock.Unlock()
+		return n.txStatus(strings.Split(hashes, ","))
+	}
+	return nil, &apiError{status: http.StatusNotFound, rpcCode: response.RPCCodeMethodNotFound,
+		msg: "the method " + method + " does not exist/is not available"}
+}
+
+// rpcBlock returns the block of a request.BlockIdOrHash, a block id or a block hash, the lock is held
+func (n *Node) rpcBlock(params request.Params) (*block, error) {
+	var raw json.RawMessage
+	if err := decodeParams(params, &raw); err != nil {
+		return nil, err
+	}
+	var by request.BlockIdOrHash
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		if id, err := strconv.ParseInt(s, 10, 64); err == nil {
+			by.Id = id
+		} else {
+			by.Hash = s
+		}
+	} else if err := json.Unmarshal(raw, &by); err != nil {
+		return nil, invalidParams("block id or hash is invalid:%s", err.Error())
+	}
+	if by.Hash != "" {
+		return n.blockByHash(by.Hash)
+	}
+	return n.block(by.Id)
+}
+
+func (n *Node) rpcTxInfo(hash string, contractInfo bool) *rpcTxInfo {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	blockID, confirm, info := n.txInfo(hash, contractInfo)
+	return &rpcTxInfo{BlockID: blockID, Confirm: confirm, Data: info}
+}
+
+// decodeParams decodes the params present into receivers, the missing ones are left unchanged
+func decodeParams(params request.Params, receivers ...any) error {
+	for k, v := range receivers {
+		if k >= len(params) {
+			break
+		}
+		if err := json.Unmarshal(params[k], v); err != nil {
+			return invalidParams("param %d is invalid:%s", k, err.Error())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package keysource
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Source provides the private key when a login or a transaction is signed, the client does not keep the key
+type Source interface {
+	PrivateKey(ctx context.Context) ([]byte, error)
+}
+
+// Func adapts a function to Source
+type Func func(ctx context.Context) ([]byte, error)
+
+func (f Func) PrivateKey(ctx context.Context) ([]byte, error) {
+	return f(ctx)
+}
+
+// Hex returns the key of the hex string key, longer keys are truncated to 32 bytes like config.Config.PrivateKey
+func Hex(key string) Source {
+	return Func(func(ctx context.Context) ([]byte, error) {
+		return DecodeHex(key)
+	})
+}
+
+// Env reads the hex key from the environment variable name at every signing
+func Env(name string) Source {
+	return Func(func(ctx context.Context) ([]byte, error) {
+		s, ok := os.LookupEnv(name)
+		if !ok {
+			return nil, fmt.Errorf("key environment variable %s is not set", name)
+		}
+		key, err := DecodeHex(s)
+		if err != nil {
+			return nil, fmt.Errorf("key environment variable %s:%w", name, err)
+		}
+		return key, nil
+	})
+}
+
+// File reads the hex key from the file at path at every signing. The file must not be readable or writable by
+// the group or others, such as 0600 or 0400
+func File(path string) Source {
+	return Func(func(ctx context.Context) ([]byte, error) {
+		data, err := readPrivate(path)
+		if err != nil {
+			return nil, err
+		}
+		key, err := DecodeHex(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("key file %s:%w", path, err)
+		}
+		return key, nil
+	})
+}
+
+// Command runs the command name with args at every signing, it writes the hex key to stdout. The command ends with ctx
+//
+//	keysource.Command("pass", "show", "ibax/key")
+func Command(name string, args ...string) Source {
+	return Func(func(ctx context.Context) ([]byte, error) {
+		var stdout, stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, name, args...)
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return nil, fmt.Errorf("key command %s failed:%w:%s", name, err, msg)
+			}
+			return nil, fmt.Errorf("key command %s failed:%w", name, err)
+		}
+		key, err := DecodeHex(stdout.String())
+		if err != nil {
+			return nil, fmt.Errorf("key command %s:%w", name, err)
+		}
+		return key, nil
+	})
+}
+
+// ErrMalformed is returned for a key that is not 64 hex characters, or 62 or 63 with the leading zeros trimmed
+var ErrMalformed = errors.New("malformed private key, want 64 hex characters")
+
+// DecodeHex decodes the hex key s, surrounding spaces and a 0x prefix are ignored, longer keys are truncated to 32 bytes.
+// The keys of 62 or 63 characters, generated with the leading zeros trimmed, are padded with zeros. Shorter keys are
+// rejected rather than padded: only 1 in 4096 random keys starts with 3 zero hex digits, so a shorter key is far more
+// likely cut by a copy error, and padding it would sign for another account. Such keys must keep their leading zeros
+func DecodeHex(s string) ([]byte, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "0x")
+	if len(s) > 64 {
+		s = s[:64]
+	}
+	if len(s) < 62 {
+		return nil, ErrMalformed
+	}
+	if len(s)%2 != 0 {
+		s = "0" + s
+	}
+	key, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	if len(key) < 32 {
+		key = append(make([]byte, 32-len(key)), key...)
+	}
+	return key, nil
+}
+
+// readPrivate reads the file at path after checking it is private to its owner
+func readPrivate(path string) ([]byte, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if perm := info.Mode().Perm(); runtime.GOOS != "windows" && perm&0o077 != 0 {
+		return nil, fmt.Errorf("key file %s has the permissions %#o, want 0600 or stricter", path, perm)
+	}
+	return os.ReadFile(path)
+}
@@ -0,0 +1,152 @@
+package keysource
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+const testKey = "ed9a1a8b7ca4f3b5d1a4d0e5e1c1d0b4f0c7a9e8d6b5a4c3b2a1f0e9d8c7b6a5"
+
+func TestDecodeHex(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+		want string
+	}{
+		{"64", testKey, testKey},
+		{"prefix and spaces", " 0x" + testKey + "\n", testKey},
+		{"truncated", testKey + "00ff", testKey},
+		{"63 leading zero trimmed", testKey[1:], "0" + testKey[1:]},
+		{"62 leading zeros trimmed", testKey[2:], "00" + testKey[2:]},
+		{"61", testKey[3:], ""},
+		{"60", testKey[4:], ""},
+		{"61 with the leading zeros", "000" + testKey[3:], "000" + testKey[3:]},
+		{"2", "ab", ""},
+		{"odd", "abc", ""},
+		{"empty", "", ""},
+		{"not hex", testKey[2:] + "zz", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := DecodeHex(tt.key)
+			if tt.want == "" {
+				if !errors.Is(err, ErrMalformed) {
+					t.Errorf("expected ErrMalformed, got %x, %v", key, err)
+				}
+				return
+			}
+			if got := hex.EncodeToString(key); err != nil || got != tt.want {
+				t.Errorf("want key %s, got %s, %v", tt.want, got, err)
+			}
+		})
+	}
+}
+
+func writeFile(t *testing.T, name string, data []byte, perm os.FileMode) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, perm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(path, perm); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func checkKey(t *testing.T, src Source, want string) {
+	t.Helper()
+	key, err := src.PrivateKey(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(key); got != want {
+		t.Errorf("want key %s, got %s", want, got)
+	}
+}
+
+func TestSources(t *testing.T) {
+	checkKey(t, Hex(testKey+"00ff"), testKey)
+	if _, err := Hex("xyz").PrivateKey(context.Background()); !errors.Is(err, ErrMalformed) {
+		t.Errorf("expected ErrMalformed, got %v", err)
+	}
+
+	t.Setenv("TEST_IBAX_KEY", "0x"+testKey+"\n")
+	checkKey(t, Env("TEST_IBAX_KEY"), testKey)
+	if _, err := Env("TEST_IBAX_KEY_MISSING").PrivateKey(context.Background()); err == nil {
+		t.Error("expected a missing variable to fail")
+	}
+
+	checkKey(t, File(writeFile(t, "key", []byte(testKey+"\n"), 0600)), testKey)
+	if runtime.GOOS != "windows" {
+		if _, err := File(writeFile(t, "key", []byte(testKey), 0644)).PrivateKey(context.Background()); err == nil {
+			t.Error("expected a key file readable by others to fail")
+		}
+	}
+
+	if _, err := exec.LookPath("sh"); err == nil {
+		checkKey(t, Command("sh", "-c", "echo "+testKey), testKey)
+		if _, err = Command("sh", "-c", "echo denied >&2; exit 1").PrivateKey(context.Background()); err == nil {
+			t.Error("expected a failed command to fail")
+		}
+	}
+}
+
+func TestKeystore(t *testing.T) {
+	n := scryptN
+	scryptN = 1 << 10
+	defer func() { scryptN = n }()
+
+	key, _ := hex.DecodeString(testKey)
+	data, err := EncryptKey(key, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := writeFile(t, "keystore.json", data, 0600)
+	src := Keystore(path, "secret")
+	checkKey(t, src, testKey)
+	if _, err = Keystore(path, "wrong").PrivateKey(context.Background()); !errors.Is(err, ErrPassphrase) {
+		t.Errorf("expected ErrPassphrase, got %v", err)
+	}
+	t.Setenv("TEST_IBAX_PASSPHRASE", "secret")
+	env := KeystoreEnv(path, "TEST_IBAX_PASSPHRASE")
+	checkKey(t, env, testKey)
+
+	// the key is kept by each source once the file is decrypted
+	if err = os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	checkKey(t, src, testKey)
+	checkKey(t, env, testKey)
+	if _, err = Keystore(path, "secret").PrivateKey(context.Background()); err == nil {
+		t.Error("expected a missing keystore file to fail")
+	}
+
+	// a failure is not kept, the file is decrypted again by the next signing
+	path = writeFile(t, "keystore.json", data, 0600)
+	later := KeystoreEnv(path, "TEST_IBAX_LATER_PASSPHRASE")
+	if _, err = later.PrivateKey(context.Background()); err == nil {
+		t.Error("expected an unset passphrase environment variable to fail")
+	}
+	t.Setenv("TEST_IBAX_LATER_PASSPHRASE", "secret")
+	checkKey(t, later, testKey)
+
+	// the pbkdf2 test vector of the Web3 Secret Storage Definition
+	vector := `{"crypto":{"cipher":"aes-128-ctr","cipherparams":{"iv":"6087dab2f9fdbbfaddc31a909735c1e6"},
+"ciphertext":"5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46","kdf":"pbkdf2",
+"kdfparams":{"c":262144,"dklen":32,"prf":"hmac-sha256","salt":"ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"},
+"mac":"517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"},
+"id":"3198bc9c-6672-5ab3-d995-4942343ae5b6","version":3}`
+	key, err = DecryptKey([]byte(vector), "testpassword")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(key); got != "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d" {
+		t.Errorf("unexpected key of the test vector %s", got)
+	}
+}
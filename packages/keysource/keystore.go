@@ -0,0 +1,106 @@
+package keysource
+
+import (
+	"context"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"os"
+	"sync"
+)
+
+// ErrPassphrase is returned when the passphrase does not decrypt the keystore
+var ErrPassphrase = keystore.ErrDecrypt
+
+// scrypt parameters of EncryptKey, the standard ones of the Ethereum keystore
+var (
+	scryptN = keystore.StandardScryptN
+	scryptP = keystore.StandardScryptP
+)
+
+// keystoreSource decrypts the keystore file at path until it succeeds, the key is then kept for the next signings
+// as scrypt takes about a second. A failure is not kept, the file is decrypted again by the next signing
+type keystoreSource struct {
+	path       string
+	passphrase func() (string, error)
+
+	lock sync.Mutex
+	key  []byte
+}
+
+// Keystore decrypts the key of the version 3 keystore file at path with passphrase. The file is decrypted by the
+// first successful signing, the key is kept by the source from then on, unlike the other sources. The files written
+// by EncryptKey, geth or other Ethereum wallets can be read
+func Keystore(path, passphrase string) Source {
+	return &keystoreSource{path: path, passphrase: func() (string, error) {
+		return passphrase, nil
+	}}
+}
+
+// KeystoreEnv is like Keystore but reads the passphrase from the environment variable name, at each signing until the
+// file is decrypted
+func KeystoreEnv(path, name string) Source {
+	return &keystoreSource{path: path, passphrase: func() (string, error) {
+		passphrase, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("passphrase environment variable %s is not set", name)
+		}
+		return passphrase, nil
+	}}
+}
+
+func (s *keystoreSource) PrivateKey(ctx context.Context) ([]byte, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.key == nil {
+		key, err := s.decrypt()
+		if err != nil {
+			return nil, err
+		}
+		s.key = key
+	}
+	return s.key, nil
+}
+
+func (s *keystoreSource) decrypt() ([]byte, error) {
+	passphrase, err := s.passphrase()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := DecryptKey(data, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("keystore %s:%w", s.path, err)
+	}
+	return key, nil
+}
+
+// EncryptKey returns the version 3 keystore file of key encrypted with passphrase, by scrypt and aes-128-ctr
+func EncryptKey(key []byte, passphrase string) ([]byte, error) {
+	privateKey, err := crypto.ToECDSA(key)
+	if err != nil {
+		return nil, err
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	return keystore.EncryptKey(&keystore.Key{
+		Id:         id,
+		Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}, passphrase, scryptN, scryptP)
+}
+
+// DecryptKey returns the key of the version 3 keystore file data, ErrPassphrase if passphrase is wrong
+func DecryptKey(data []byte, passphrase string) ([]byte, error) {
+	key, err := keystore.DecryptKey(data, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.FromECDSA(key.PrivateKey), nil
+}
@@ -0,0 +1,78 @@
+package logger
+
+import (
+	"sync/atomic"
+)
+
+// Logger receives the logs of the SDK, keyvals are alternating keys and values like the ones of slog.
+// A client logs through the Logger of transport.WithLogger, also when it builds transactions by the pkg packages,
+// the pkg packages used without a client log through Default
+type Logger interface {
+	Debug(msg string, keyvals ...any)
+	Info(msg string, keyvals ...any)
+	Warn(msg string, keyvals ...any)
+	Error(msg string, keyvals ...any)
+}
+
+// debugEnabler is implemented by the loggers that can skip the debug level,
+// the request and response dumps are built only if it is enabled
+type debugEnabler interface {
+	DebugEnabled() bool
+}
+
+// DebugEnabled reports whether l logs the debug level, true if l does not tell
+func DebugEnabled(l Logger) bool {
+	if d, ok := l.(debugEnabler); ok {
+		return d.DebugEnabled()
+	}
+	return true
+}
+
+type nop struct{}
+
+func (nop) Debug(string, ...any) {}
+func (nop) Info(string, ...any)  {}
+func (nop) Warn(string, ...any)  {}
+func (nop) Error(string, ...any) {}
+func (nop) DebugEnabled() bool   { return false }
+
+// Nop discards the logs, it is the default logger
+var Nop Logger = nop{}
+
+type holder struct {
+	Logger
+}
+
+var defaultLogger atomic.Value
+
+// Default returns the logger of the packages logging without a client, Nop if SetDefault is not called
+func Default() Logger {
+	if h, ok := defaultLogger.Load().(holder); ok {
+		return h.Logger
+	}
+	return Nop
+}
+
+// SetDefault sets the logger returned by Default, nil restores Nop
+func SetDefault(l Logger) {
+	if l == nil {
+		l = Nop
+	}
+	defaultLogger.Store(holder{l})
+}
+
+// Of returns the logger of a client or base, Default if v has none
+func Of(v any) Logger {
+	if l, ok := v.(interface{ Logger() Logger }); ok {
+		return OrNop(l.Logger())
+	}
+	return Default()
+}
+
+// OrNop returns l, or Nop if l is nil
+func OrNop(l Logger) Logger {
+	if l == nil {
+		return Nop
+	}
+	return l
+}
@@ -0,0 +1,70 @@
+package logger
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type entry struct {
+	level, msg string
+}
+
+type recordLogger struct {
+	entries []entry
+}
+
+func (r *recordLogger) Debug(msg string, _ ...any) {
+	r.entries = append(r.entries, entry{"debug", msg})
+}
+func (r *recordLogger) Info(msg string, _ ...any) { r.entries = append(r.entries, entry{"info", msg}) }
+func (r *recordLogger) Warn(msg string, _ ...any) { r.entries = append(r.entries, entry{"warn", msg}) }
+func (r *recordLogger) Error(msg string, _ ...any) {
+	r.entries = append(r.entries, entry{"error", msg})
+}
+
+func TestDefault(t *testing.T) {
+	if Default() != Nop || DebugEnabled(Nop) {
+		t.Fatal("want the Nop default logger without debug")
+	}
+	l := &recordLogger{}
+	SetDefault(l)
+	defer SetDefault(nil)
+	Default().Error("failed")
+	if len(l.entries) != 1 || l.entries[0] != (entry{"error", "failed"}) || !DebugEnabled(Default()) {
+		t.Errorf("unexpected entries %v", l.entries)
+	}
+	if Of(struct{}{}) != l {
+		t.Error("want Default for a value without logger")
+	}
+	SetDefault(nil)
+	if Default() != Nop || OrNop(nil) != Nop {
+		t.Error("want Nop restored")
+	}
+}
+
+func TestRedact(t *testing.T) {
+	h := http.Header{"Authorization": {"Bearer secret"}, "Content-Type": {"application/json"}}
+	if got := RedactHeader(h); got.Get("Authorization") != Redacted || got.Get("Content-Type") != "application/json" ||
+		h.Get("Authorization") != "Bearer secret" {
+		t.Errorf("unexpected headers %v", got)
+	}
+	cases := []struct {
+		contentType, body, want string
+	}{
+		{"application/x-www-form-urlencoded", "signature=abc&pubkey=04", "pubkey=04&signature=" + Redacted},
+		{"multipart/form-data; boundary=x", "--x\r\n", ""},
+		{"application/json", `{"result":{"token":"secret","ecosystem_id":"1"}}`,
+			`{"result":{"ecosystem_id":"1","token":"` + Redacted + `"}}`},
+		{"application/json", `{"jsonrpc":"2.0","method":"ibax.sendTx","params":[{"abc":"ZGF0YQ=="}]}`, Redacted},
+		{"application/json", `[{"method":"ibax.getUid"}]`, `[{"method":"ibax.getUid"}]`},
+	}
+	for _, v := range cases {
+		if got := RedactBody(v.contentType, []byte(v.body)); got != v.want {
+			t.Errorf("%s: want %s, got %s", v.body, v.want, got)
+		}
+		if strings.Contains(RedactBody(v.contentType, []byte(v.body)), "secret") {
+			t.Errorf("%s: secret is logged", v.body)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package logger
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Redacted replaces the tokens, signatures and keys in the logs
+const Redacted = "REDACTED"
+
+// redactKeys are the json and form fields replaced by Redacted
+var redactKeys = map[string]bool{
+	"token":       true,
+	"signature":   true,
+	"private_key": true,
+	"notify_key":  true,
+}
+
+// redactHeaders are the headers replaced by Redacted
+var redactHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// RedactHeader returns a copy of h without the credentials
+func RedactHeader(h http.Header) http.Header {
+	h = h.Clone()
+	for _, key := range redactHeaders {
+		if h.Get(key) != "" {
+			h.Set(key, Redacted)
+		}
+	}
+	return h
+}
+
+// RedactBody returns a request or response body of contentType without tokens, signatures and transaction data.
+// A multipart body is dropped, the transactions of a sendTx JSON-RPC request are replaced by Redacted
+func RedactBody(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "multipart/form-data":
+		return ""
+	case "application/x-www-form-urlencoded":
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return Redacted
+		}
+		for key := range form {
+			if redactKeys[key] {
+				form.Set(key, Redacted)
+			}
+		}
+		return form.Encode()
+	}
+	var v any
+	if json.Unmarshal(body, &v) == nil && sendsTx(v) {
+		return Redacted
+	}
+	return string(RedactJSON(body))
+}
+
+// sendsTx reports whether v is a sendTx JSON-RPC request, or a batch with one
+func sendsTx(v any) bool {
+	switch vt := v.(type) {
+	case map[string]any:
+		return vt["method"] == "ibax.sendTx"
+	case []any:
+		for _, value := range vt {
+			if sendsTx(value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RedactJSON replaces the values of the token, signature and key fields in a json document,
+// other data is returned unchanged
+func RedactJSON(data []byte) []byte {
+	var v any
+	if json.Unmarshal(data, &v) != nil {
+		return data
+	}
+	if !redactValue(v) {
+		return data
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		return data
+	}
+	return out
+}
+
+func redactValue(v any) (changed bool) {
+	switch vt := v.(type) {
+	case map[string]any:
+		for key, value := range vt {
+			if s, ok := value.(string); ok && redactKeys[strings.ToLower(key)] && s != "" {
+				vt[key] = Redacted
+				changed = true
+				continue
+			}
+			if redactValue(value) {
+				changed = true
+			}
+		}
+	case []any:
+		for _, value := range vt {
+			if redactValue(value) {
+				changed = true
+			}
+		}
+	}
+	return
+}
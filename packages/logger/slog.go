@@ -0,0 +1,29 @@
+//go:build go1.21
+
+package logger
+
+import (
+	"context"
+	"log/slog"
+)
+
+type slogLogger struct {
+	l *slog.Logger
+}
+
+// Slog adapts l to Logger, slog.Default() if l is nil
+func Slog(l *slog.Logger) Logger {
+	if l == nil {
+		l = slog.Default()
+	}
+	return slogLogger{l: l}
+}
+
+func (s slogLogger) Debug(msg string, keyvals ...any) { s.l.Debug(msg, keyvals...) }
+func (s slogLogger) Info(msg string, keyvals ...any)  { s.l.Info(msg, keyvals...) }
+func (s slogLogger) Warn(msg string, keyvals ...any)  { s.l.Warn(msg, keyvals...) }
+func (s slogLogger) Error(msg string, keyvals ...any) { s.l.Error(msg, keyvals...) }
+
+func (s slogLogger) DebugEnabled() bool {
+	return s.l.Enabled(context.Background(), slog.LevelDebug)
+}
@@ -0,0 +1,24 @@
+//go:build go1.21
+
+package logger
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSlog(t *testing.T) {
+	var buf bytes.Buffer
+	l := Slog(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})))
+	if DebugEnabled(l) {
+		t.Error("debug must be disabled at the info level")
+	}
+	l.Debug("skipped")
+	l.Warn("request failed", "attempt", 2)
+	if out := buf.String(); strings.Contains(out, "skipped") || !strings.Contains(out, "level=WARN") ||
+		!strings.Contains(out, `msg="request failed" attempt=2`) {
+		t.Errorf("unexpected output %s", out)
+	}
+}
@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"time"
+)
+
+// Metrics receives the measures of a client, set by transport.WithMetrics
+type Metrics interface {
+	// ObserveRequest is called after every attempt of a call sent to a node endpoint, err is the error of the attempt
+	ObserveRequest(method, endpoint string, elapsed time.Duration, err error)
+	// IncRetry is called before a failed call is sent again
+	IncRetry(method, endpoint string)
+	// IncTokenRefresh is called after the client logs in again, err is the error of the login
+	IncTokenRefresh(err error)
+	// AddTxSent is called after count transactions are sent
+	AddTxSent(count int)
+	// ObserveTxConfirmed is called when a transaction is found in a block, elapsed since the status is waited for,
+	// penalty reports whether the transaction failed
+	ObserveTxConfirmed(elapsed time.Duration, penalty bool)
+}
+
+type nop struct{}
+
+func (nop) ObserveRequest(string, string, time.Duration, error) {}
+func (nop) IncRetry(string, string)                             {}
+func (nop) IncTokenRefresh(error)                               {}
+func (nop) AddTxSent(int)                                       {}
+func (nop) ObserveTxConfirmed(time.Duration, bool)              {}
+
+// Nop discards the measures, it is the default metrics
+var Nop Metrics = nop{}
+
+// OrNop returns m, or Nop if m is nil
+func OrNop(m Metrics) Metrics {
+	if m == nil {
+		return Nop
+	}
+	return m
+}
+
+// Of returns the metrics of a client or base, Nop if v has none
+func Of(v any) Metrics {
+	if m, ok := v.(interface{ Metrics() Metrics }); ok {
+		return OrNop(m.Metrics())
+	}
+	return Nop
+}
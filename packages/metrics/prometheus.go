@@ -0,0 +1,262 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Buckets of the histograms of Registry in seconds
+var (
+	RequestBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+	TxBuckets      = []float64{.5, 1, 2, 5, 10, 20, 30, 60, 120}
+)
+
+// Registry keeps the measures in memory and writes them in the Prometheus text exposition format,
+// it is served by ServeHTTP without any external service:
+//
+//	ibax_sdk_requests_total{method,endpoint}           attempts of the calls sent to the node endpoints
+//	ibax_sdk_request_errors_total{method,endpoint}     failed attempts
+//	ibax_sdk_request_duration_seconds{method,endpoint} latency of the attempts
+//	ibax_sdk_retries_total{method,endpoint}            failed calls sent again
+//	ibax_sdk_token_refreshes_total{result}             logins again, result is ok or error
+//	ibax_sdk_txs_sent_total                            transactions sent
+//	ibax_sdk_tx_confirmation_seconds                   time until the transactions are found in a block
+//	ibax_sdk_tx_penalties_total                        transactions failed with a penalty
+type Registry struct {
+	lock          sync.Mutex
+	requests      counterVec
+	requestErrors counterVec
+	requestTime   histogramVec
+	retries       counterVec
+	refreshes     counterVec
+	txsSent       counterVec
+	txConfirm     histogramVec
+	txPenalties   counterVec
+}
+
+var _ Metrics = (*Registry)(nil)
+
+// NewRegistry creates an empty Registry
+func NewRegistry() *Registry {
+	return &Registry{
+		requests:      counterVec{name: "ibax_sdk_requests_total", help: "Attempts of the calls sent to the node endpoints."},
+		requestErrors: counterVec{name: "ibax_sdk_request_errors_total", help: "Failed attempts of the calls sent to the node endpoints."},
+		requestTime: histogramVec{name: "ibax_sdk_request_duration_seconds", help: "Latency of the attempts of the calls.",
+			buckets: RequestBuckets},
+		retries:   counterVec{name: "ibax_sdk_retries_total", help: "Failed calls sent again."},
+		refreshes: counterVec{name: "ibax_sdk_token_refreshes_total", help: "Logins again to refresh the token."},
+		txsSent:   counterVec{name: "ibax_sdk_txs_sent_total", help: "Transactions sent.", unlabeled: true},
+		txConfirm: histogramVec{name: "ibax_sdk_tx_confirmation_seconds", help: "Time until the transactions are found in a block.",
+			buckets: TxBuckets},
+		txPenalties: counterVec{name: "ibax_sdk_tx_penalties_total", help: "Transactions failed with a penalty.", unlabeled: true},
+	}
+}
+
+func (r *Registry) ObserveRequest(method, endpoint string, elapsed time.Duration, err error) {
+	labels := labelPairs("method", method, "endpoint", endpoint)
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.requests.add(labels, 1)
+	if err != nil {
+		r.requestErrors.add(labels, 1)
+	}
+	r.requestTime.observe(labels, elapsed.Seconds())
+}
+
+func (r *Registry) IncRetry(method, endpoint string) {
+	labels := labelPairs("method", method, "endpoint", endpoint)
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.retries.add(labels, 1)
+}
+
+func (r *Registry) IncTokenRefresh(err error) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	labels := labelPairs("result", result)
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.refreshes.add(labels, 1)
+}
+
+func (r *Registry) AddTxSent(count int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.txsSent.add("", float64(count))
+}
+
+func (r *Registry) ObserveTxConfirmed(elapsed time.Duration, penalty bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.txConfirm.observe("", elapsed.Seconds())
+	if penalty {
+		r.txPenalties.add("", 1)
+	}
+}
+
+// WriteTo writes the measures to w in the Prometheus text exposition format
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	cw := &countWriter{w: bufio.NewWriter(w)}
+	r.lock.Lock()
+	r.requests.write(cw)
+	r.requestErrors.write(cw)
+	r.requestTime.write(cw)
+	r.retries.write(cw)
+	r.refreshes.write(cw)
+	r.txsSent.write(cw)
+	r.txConfirm.write(cw)
+	r.txPenalties.write(cw)
+	r.lock.Unlock()
+	if cw.err == nil {
+		cw.err = cw.w.Flush()
+	}
+	return cw.n, cw.err
+}
+
+// ServeHTTP serves the measures to a Prometheus scraper
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	r.WriteTo(w)
+}
+
+// counterVec is a counter by label pairs, the labels are formatted by labelPairs
+type counterVec struct {
+	name, help string
+	unlabeled  bool // exposed as 0 before the first measure
+	values     map[string]float64
+}
+
+func (c *counterVec) add(labels string, v float64) {
+	if c.values == nil {
+		c.values = make(map[string]float64)
+	}
+	c.values[labels] += v
+}
+
+func (c *counterVec) write(w *countWriter) {
+	w.printf("# HELP %s %s\n# TYPE %s counter\n", c.name, c.help, c.name)
+	if len(c.values) == 0 && c.unlabeled {
+		w.printf("%s 0\n", c.name)
+		return
+	}
+	for _, labels := range sortedKeys(c.values) {
+		w.printf("%s%s %s\n", c.name, braces(labels), formatFloat(c.values[labels]))
+	}
+}
+
+type histogram struct {
+	counts []uint64 // cumulative count of each bucket
+	count  uint64
+	sum    float64
+}
+
+// histogramVec is a histogram by label pairs, the labels are formatted by labelPairs
+type histogramVec struct {
+	name, help string
+	buckets    []float64
+	values     map[string]*histogram
+}
+
+func (h *histogramVec) observe(labels string, v float64) {
+	if h.values == nil {
+		h.values = make(map[string]*histogram)
+	}
+	hist, ok := h.values[labels]
+	if !ok {
+		hist = &histogram{counts: make([]uint64, len(h.buckets))}
+		h.values[labels] = hist
+	}
+	for k, le := range h.buckets {
+		if v <= le {
+			hist.counts[k]++
+		}
+	}
+	hist.count++
+	hist.sum += v
+}
+
+func (h *histogramVec) write(w *countWriter) {
+	w.printf("# HELP %s %s\n# TYPE %s histogram\n", h.name, h.help, h.name)
+	for _, labels := range sortedKeys(h.values) {
+		hist := h.values[labels]
+		sep := ""
+		if labels != "" {
+			sep = ","
+		}
+		for k, le := range h.buckets {
+			w.printf("%s_bucket{%s%sle=%q} %d\n", h.name, labels, sep, formatFloat(le), hist.counts[k])
+		}
+		w.printf("%s_bucket{%s%sle=\"+Inf\"} %d\n", h.name, labels, sep, hist.count)
+		w.printf("%s_sum%s %s\n", h.name, braces(labels), formatFloat(hist.sum))
+		w.printf("%s_count%s %d\n", h.name, braces(labels), hist.count)
+	}
+}
+
+// labelPairs formats the alternating names and values as name="value" pairs separated by commas
+func labelPairs(nameValues ...string) string {
+	var b strings.Builder
+	for k := 0; k+1 < len(nameValues); k += 2 {
+		if k > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(nameValues[k])
+		b.WriteString(`="`)
+		b.WriteString(labelEscaper.Replace(nameValues[k+1]))
+		b.WriteByte('"')
+	}
+	return b.String()
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func braces(labels string) string {
+	if labels == "" {
+		return ""
+	}
+	return "{" + labels + "}"
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// countWriter counts the bytes written and keeps the first error
+type countWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (c *countWriter) printf(format string, args ...any) {
+	if c.err != nil {
+		return
+	}
+	n, err := fmt.Fprintf(c.w, format, args...)
+	c.n += int64(n)
+	c.err = err
+}
@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	var b strings.Builder
+	if _, err := r.WriteTo(&b); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), "ibax_sdk_txs_sent_total 0\n") || strings.Contains(b.String(), "ibax_sdk_requests_total{") {
+		t.Errorf("unexpected empty registry:\n%s", b.String())
+	}
+
+	r.ObserveRequest("getuid", "http://node1", 20*time.Millisecond, nil)
+	r.ObserveRequest("getuid", "http://node1", 2*time.Second, errors.New("timeout"))
+	r.ObserveRequest(`ibax."sendTx"`, "http://node2", time.Millisecond, nil)
+	r.IncRetry("getuid", "http://node1")
+	r.IncTokenRefresh(nil)
+	r.IncTokenRefresh(errors.New("login failed"))
+	r.AddTxSent(2)
+	r.ObserveTxConfirmed(1500*time.Millisecond, false)
+	r.ObserveTxConfirmed(3*time.Second, true)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("unexpected content type %s", ct)
+	}
+	out := w.Body.String()
+	for _, want := range []string{
+		"# TYPE ibax_sdk_requests_total counter\n",
+		`ibax_sdk_requests_total{method="getuid",endpoint="http://node1"} 2` + "\n",
+		`ibax_sdk_requests_total{method="ibax.\"sendTx\"",endpoint="http://node2"} 1` + "\n",
+		`ibax_sdk_request_errors_total{method="getuid",endpoint="http://node1"} 1` + "\n",
+		"# TYPE ibax_sdk_request_duration_seconds histogram\n",
+		`ibax_sdk_request_duration_seconds_bucket{method="getuid",endpoint="http://node1",le="0.025"} 1` + "\n",
+		`ibax_sdk_request_duration_seconds_bucket{method="getuid",endpoint="http://node1",le="+Inf"} 2` + "\n",
+		`ibax_sdk_request_duration_seconds_sum{method="getuid",endpoint="http://node1"} 2.02` + "\n",
+		`ibax_sdk_request_duration_seconds_count{method="getuid",endpoint="http://node1"} 2` + "\n",
+		`ibax_sdk_retries_total{method="getuid",endpoint="http://node1"} 1` + "\n",
+		`ibax_sdk_token_refreshes_total{result="error"} 1` + "\n",
+		`ibax_sdk_token_refreshes_total{result="ok"} 1` + "\n",
+		"ibax_sdk_txs_sent_total 2\n",
+		`ibax_sdk_tx_confirmation_seconds_bucket{le="2"} 1` + "\n",
+		"ibax_sdk_tx_confirmation_seconds_sum 4.5\n",
+		"ibax_sdk_tx_confirmation_seconds_count 2\n",
+		"ibax_sdk_tx_penalties_total 1\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package modus
 
-import "github.com/IBAX-io/go-ibax-sdk/packages/response"
+import (
+	"context"
+	"github.com/IBAX-io/go-ibax-sdk/packages/response"
+)
 
 type Authentication interface {
 	// GetUid
@@ -13,9 +16,27 @@ type Authentication interface {
 	// @method post
 	// Log in to the specified role and save the token
 	Login(roleId int64) (err error)
+	// LoginResponse
+	// like Login, and returns the roles of the key, the notify key and whether the key is the owner or a node
+	LoginResponse(roleId int64) (*response.LoginResult, error)
+	// LoginAs
+	// log in to the role named roleName of the key in the ecosystem of the config, after GetUid like Login
+	LoginAs(roleName string) (*response.LoginResult, error)
+	// Roles
+	// the roles of the key of the config in the ecosystem of the config
+	Roles() ([]response.RoleInfo, error)
 	// AutoLogin
-	// no role auto login
+	// auto login to the RoleId of the config, no role by default
 	AutoLogin() error
 	GetAuthStatus() (*response.AuthStatusResponse, error)
 	GetUidResponse() (*response.GetUIDResult, error)
+
+	GetUidContext(ctx context.Context) error
+	LoginContext(ctx context.Context, roleId int64) (err error)
+	LoginResponseContext(ctx context.Context, roleId int64) (*response.LoginResult, error)
+	LoginAsContext(ctx context.Context, roleName string) (*response.LoginResult, error)
+	RolesContext(ctx context.Context) ([]response.RoleInfo, error)
+	AutoLoginContext(ctx context.Context) error
+	GetAuthStatusContext(ctx context.Context) (*response.AuthStatusResponse, error)
+	GetUidResponseContext(ctx context.Context) (*response.GetUIDResult, error)
 }
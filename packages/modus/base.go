@@ -1,16 +1,25 @@
 package modus
 
 import (
+	"context"
 	"github.com/IBAX-io/go-ibax-sdk/config"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/cryptoer"
 	"github.com/IBAX-io/go-ibax-sdk/packages/request"
+	"github.com/IBAX-io/go-ibax-sdk/packages/signer"
 	"net/url"
 )
 
 type Base interface {
 	Init() (err error)
+	// Crypto returns the algorithms of the cryptoer and hasher of the config, nil if they are not configured
+	Crypto() cryptoer.Provider
+	// Signer returns the signer of the config, else the one of the private key of the config
+	Signer() signer.Signer
 	GetConfig() config.Config
 	SetConfig(config.Config)
 	Version() string
+	// Close stops the session keeper and closes the websocket connections to the nodes
+	Close() error
 	AmountValidator(amount string) error
 	ExpediteValidator(expedite string) error
 
@@ -19,11 +28,16 @@ type Base interface {
 	SendPost(url string, form *url.Values, result any) error
 	//SendPostByFile(url string, form *url.Values, fileName string) error
 	SendMultipart(url string, files map[string][]byte, result any) error
+	SendGetContext(ctx context.Context, url string, form *url.Values, result any) error
+	SendPostContext(ctx context.Context, url string, form *url.Values, result any) error
+	SendMultipartContext(ctx context.Context, url string, files map[string][]byte, result any) error
 
 	// JSON-RPC Request
 	NewMessage(params request.RequestParams) (request.Request, error)
 	NewBatchMessage(requestPrams []request.BatchRequestParams) ([]request.BatchRequest, error)
 	GET(form any, result any) error
 	POST(form any, result any) error
+	GETContext(ctx context.Context, form any, result any) error
+	POSTContext(ctx context.Context, form any, result any) error
 	//POSTByFile(form any, fileName string) error
 }
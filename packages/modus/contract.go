@@ -1,6 +1,9 @@
 package modus
 
-import "github.com/IBAX-io/go-ibax-sdk/packages/response"
+import (
+	"context"
+	"github.com/IBAX-io/go-ibax-sdk/packages/response"
+)
 
 type Getter interface {
 	Get(string) string
@@ -32,4 +35,9 @@ type Contract interface {
 	// AutoCallContract
 	// call Contract and return transaction result
 	AutoCallContract(contractName string, form Getter, expedite string) (*response.TxStatusResult, error)
+
+	GetContractsContext(ctx context.Context, limit, offset int64) (*response.ListResult, error)
+	GetContractContext(ctx context.Context, contractName string) (*response.GetContractResult, error)
+	PrepareContractTxContext(ctx context.Context, contractName string, form Getter) (params map[string]any, contractId uint32, err error)
+	AutoCallContractContext(ctx context.Context, contractName string, form Getter, expedite string) (*response.TxStatusResult, error)
 }
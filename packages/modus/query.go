@@ -1,6 +1,7 @@
 package modus
 
 import (
+	"context"
 	"github.com/IBAX-io/go-ibax-sdk/packages/request"
 	"github.com/IBAX-io/go-ibax-sdk/packages/response"
 )
@@ -31,6 +32,11 @@ type Query interface {
 	//BinaryVerify Save the response result to filename,if filename is null, then return result
 	BinaryVerify(id int64, hash, fileName string) (result request.FileType, err error)
 	GetAvatar(account string, ecosystem int64, fileName string) (result request.FileType, err error)
+	//DataVerifyTo, BinaryVerifyTo and GetAvatarTo stream the response into the writer of stream instead of buffering it,
+	//the content type, length and hash are set to stream
+	DataVerifyTo(stream *response.FileStream, tableName string, id int64, column, hash string) error
+	BinaryVerifyTo(stream *response.FileStream, id int64, hash string) error
+	GetAvatarTo(stream *response.FileStream, account string, ecosystem int64) error
 
 	//block
 	DetailedBlocks(block, count int64) (*map[int64]response.BlockDetailedInfo, error)
@@ -64,4 +70,61 @@ type Query interface {
 	DetailedBlock(blockIdOrBlockHash any) (*response.BlockDetailedInfo, error)
 	EcosystemInfo(ecosystem int64) (*response.EcosystemInfo, error)
 	GetMemberInfo(account string, ecosystem int64) (*response.MemberInfo, error)
+
+	// The XxxContext variants below behave like the calls above,
+	// ctx is used to cancel the request or bound it by a deadline
+	GetKeyInfoContext(ctx context.Context, account string) (*response.KeyInfoResult, error)
+	BalanceContext(ctx context.Context, wallet string, ecosystem int64) (*response.TokenBalanceResult, error)
+	GetBalanceContext(ctx context.Context, wallet string) (*response.TokenBalanceResult, error)
+
+	GetVersionContext(ctx context.Context) (*string, error)
+	GetIBAXConfigContext(ctx context.Context, option string) (*string, error)
+
+	//metrics
+	EcosystemCountContext(ctx context.Context) (int64, error)
+	GetMaxBlockIDContext(ctx context.Context) (int64, error)
+	TransactionsCountContext(ctx context.Context) (int64, error)
+	KeysCountContext(ctx context.Context) (int64, error)
+	HonorNodesCountContext(ctx context.Context) (int64, error)
+	BlocksCountContext(ctx context.Context) (int64, error)
+
+	DataVerifyContext(ctx context.Context, tableName string, id int64, column, hash, fileName string) (result request.FileType, err error)
+	BinaryVerifyContext(ctx context.Context, id int64, hash, fileName string) (result request.FileType, err error)
+	GetAvatarContext(ctx context.Context, account string, ecosystem int64, fileName string) (result request.FileType, err error)
+	DataVerifyToContext(ctx context.Context, stream *response.FileStream, tableName string, id int64, column, hash string) error
+	BinaryVerifyToContext(ctx context.Context, stream *response.FileStream, id int64, hash string) error
+	GetAvatarToContext(ctx context.Context, stream *response.FileStream, account string, ecosystem int64) error
+
+	//block
+	DetailedBlocksContext(ctx context.Context, block, count int64) (*map[int64]response.BlockDetailedInfo, error)
+	GetBlockInfoContext(ctx context.Context, id int64) (*response.BlockInfoResult, error)
+	BlocksTxInfoContext(ctx context.Context, block, count int64) (*map[int64][]response.BlockTxInfo, error)
+
+	//ecosystem
+	GetTableCountContext(ctx context.Context, offset, limit int) (*response.TablesResult, error)
+	GetTableContext(ctx context.Context, tableName string) (*response.TableResult, error)
+	GetSectionsContext(ctx context.Context, language string, offset, limit int) (*response.ListResult, error)
+	GetPageRowContext(ctx context.Context, name string) (*response.PageResult, error)
+	GetMenuRowContext(ctx context.Context, name string) (*response.MenuResult, error)
+	GetSnippetRowContext(ctx context.Context, name string) (*response.SnippetResult, error)
+	GetAppContentContext(ctx context.Context, appId int64) (*response.AppContentResult, error)
+
+	//ecosystem
+	EcosystemNameContext(ctx context.Context, ecosystem int64) (*response.EcosystemNameResult, error)
+	AppParamsContext(ctx context.Context, appid int64, names string, ecosystem int64, params ...int) (*response.AppParamsResult, error)
+	AppParamContext(ctx context.Context, appid int64, name string, ecosystem int64) (*response.ParamResult, error)
+	EcosystemParamContext(ctx context.Context, ecosystem int64, name string) (*response.ParamResult, error)
+	EcosystemParamsContext(ctx context.Context, ecosystem int64, names string, params ...int) (*response.ParamsResult, error)
+	SystemParamsContext(ctx context.Context, names string, params ...int) (*response.ParamsResult, error)
+	GetRowContext(ctx context.Context, tableName string, id int64, columns string, whereColumn string) (*response.RowResult, error)
+	GetRowExtendContext(ctx context.Context, tableName, columns string, value string, rowsName string) (*response.RowResult, error)
+	GetHistoryContext(ctx context.Context, table string, id uint64) (*response.HistoryResult, error)
+	GetListContext(ctx context.Context, params request.GetList) (*response.ListResult, error)
+	GetListWhereContext(ctx context.Context, params request.GetList) (*response.ListResult, error)
+	GetNodeListWhereContext(ctx context.Context, params request.GetList) (*response.ListResult, error)
+
+	BlockTxCountContext(ctx context.Context, blockIdOrBlockHash any) (int64, error)
+	DetailedBlockContext(ctx context.Context, blockIdOrBlockHash any) (*response.BlockDetailedInfo, error)
+	EcosystemInfoContext(ctx context.Context, ecosystem int64) (*response.EcosystemInfo, error)
+	GetMemberInfoContext(ctx context.Context, account string, ecosystem int64) (*response.MemberInfo, error)
 }
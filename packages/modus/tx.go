@@ -1,6 +1,7 @@
 package modus
 
 import (
+	"context"
 	"github.com/IBAX-io/go-ibax-sdk/packages/response"
 	"time"
 )
@@ -21,6 +22,12 @@ type Transaction interface {
 
 	GetTxInfo(hash string, getContractInfo bool) (*response.TxInfoResult, error)
 	GetTxInfoMulti(hashList []string, getContractInfo bool) (*response.MultiTxInfoResult, error)
+
+	SendTxContext(ctx context.Context, arrData map[string][]byte) (hashMap *map[string]string, err error)
+	TxStatusContext(ctx context.Context, hash string, frequency int, interval time.Duration) (response.TxStatusResult, error)
+	TxsStatusContext(ctx context.Context, hashList []string, interval time.Duration) (map[string]response.TxStatusResult, error)
+	GetTxInfoContext(ctx context.Context, hash string, getContractInfo bool) (*response.TxInfoResult, error)
+	GetTxInfoMultiContext(ctx context.Context, hashList []string, getContractInfo bool) (*response.MultiTxInfoResult, error)
 }
 
 //
@@ -1,6 +1,7 @@
 package modus
 
 import (
+	"context"
 	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/types"
 	"github.com/IBAX-io/go-ibax-sdk/packages/request"
 	"github.com/IBAX-io/go-ibax-sdk/packages/response"
@@ -12,4 +13,6 @@ type Utxo interface {
 	NewUtxoSmartTransaction(txType request.UtxoType, form Getter, expedite string) (*types.SmartTransaction, error)
 	NewUtxoTransaction(smartTransaction types.SmartTransaction) (data, hash []byte, err error)
 	AutoCallUtxo(txType request.UtxoType, form Getter, expedite string) (*response.TxStatusResult, error)
+
+	AutoCallUtxoContext(ctx context.Context, txType request.UtxoType, form Getter, expedite string) (*response.TxStatusResult, error)
 }
@@ -12,6 +12,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/IBAX-io/go-ibax-sdk/packages/logger"
 	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/consts"
 	"math"
 	"reflect"
@@ -23,7 +24,6 @@ import (
 	"unicode"
 
 	"github.com/shopspring/decimal"
-	log "github.com/sirupsen/logrus"
 )
 
 var ErrSliceSize = errors.New("Slice size larger than buffer size")
@@ -103,7 +103,7 @@ func DecodeLenInt64(data *[]byte) (int64, error) {
 	}
 	length := int((*data)[0]) + 1
 	if len(*data) < length {
-		log.WithFields(log.Fields{"data_length": len(*data), "length": length, "type": consts.UnmarshallingError}).Error("length of data is smaller then encoded length")
+		logger.Default().Error("length of data is smaller then encoded length", "data_length", len(*data), "length", length, "type", consts.UnmarshallingError)
 		return 0, fmt.Errorf(`length of data %d < %d`, len(*data), length)
 	}
 	buf := make([]byte, 8)
@@ -120,13 +120,13 @@ func DecodeLenInt64Buf(buf *bytes.Buffer) (int64, error) {
 
 	val, err := buf.ReadByte()
 	if err != nil {
-		log.WithFields(log.Fields{"type": consts.IOError, "error": err}).Error("cannot read bytes from buffer")
+		logger.Default().Error("cannot read bytes from buffer", "type", consts.IOError, "error", err)
 		return 0, err
 	}
 
 	length := int(val)
 	if buf.Len() < length {
-		log.WithFields(log.Fields{"type": consts.UnmarshallingError, "data_length": buf.Len(), "length": length}).Error("length of data is smaller then encoded length")
+		logger.Default().Error("length of data is smaller then encoded length", "type", consts.UnmarshallingError, "data_length", buf.Len(), "length", length)
 		return 0, fmt.Errorf(`length of data %d < %d`, buf.Len(), length)
 	}
 	data := make([]byte, 8)
@@ -149,7 +149,7 @@ func DecodeLength(buf *[]byte) (ret int64, err error) {
 	if (length & 0x80) != 0 {
 		length &= 0x7F
 		if len(*buf) < int(length+1) {
-			log.WithFields(log.Fields{"data_length": len(*buf), "length": int(length + 1)}).Error("length of data is smaller then encoded length")
+			logger.Default().Error("length of data is smaller then encoded length", "data_length", len(*buf), "length", int(length+1))
 			return 0, fmt.Errorf(`input slice has small size`)
 		}
 		ret = int64(binary.BigEndian.Uint64(append(make([]byte, 8-length), (*buf)[1:length+1]...)))
@@ -168,7 +168,7 @@ func DecodeLengthBuf(buf *bytes.Buffer) (int, error) {
 
 	length, err := buf.ReadByte()
 	if err != nil {
-		log.WithFields(log.Fields{"type": consts.IOError, "error": err}).Error("cannot read bytes from buffer")
+		logger.Default().Error("cannot read bytes from buffer", "type", consts.IOError, "error", err)
 		return 0, err
 	}
 
@@ -178,7 +178,7 @@ func DecodeLengthBuf(buf *bytes.Buffer) (int, error) {
 
 	length &= 0x7F
 	if buf.Len() < int(length) {
-		log.WithFields(log.Fields{"data_length": buf.Len(), "length": int(length), "type": consts.UnmarshallingError}).Error("length of data is smaller then encoded length")
+		logger.Default().Error("length of data is smaller then encoded length", "data_length", buf.Len(), "length", int(length), "type", consts.UnmarshallingError)
 		return 0, fmt.Errorf(`input slice has small size`)
 	}
 
@@ -267,7 +267,7 @@ func BinUnmarshalBuff(buf *bytes.Buffer, v any) error {
 		t = t.Elem()
 	}
 	if buf.Len() == 0 {
-		log.WithFields(log.Fields{"type": consts.UnmarshallingError, "error": "input slice is empty"}).Error("input slice is empty")
+		logger.Default().Error("input slice is empty", "type", consts.UnmarshallingError, "error", "input slice is empty")
 		return fmt.Errorf(`input slice is empty`)
 	}
 	switch t.Kind() {
@@ -284,7 +284,7 @@ func BinUnmarshalBuff(buf *bytes.Buffer, v any) error {
 	case reflect.Int32:
 		val, err := buf.ReadByte()
 		if err != nil {
-			log.WithFields(log.Fields{"type": consts.IOError, "error": err}).Error("reading bytes from buffer")
+			logger.Default().Error("reading bytes from buffer", "type", consts.IOError, "error", err)
 			return err
 		}
 		if val < 128 {
@@ -294,13 +294,13 @@ func BinUnmarshalBuff(buf *bytes.Buffer, v any) error {
 			size := val - 128
 			tmp := make([]byte, 4)
 			if buf.Len() <= int(size) || size > 4 {
-				log.WithFields(log.Fields{"type": consts.UnmarshallingError, "data_length": buf.Len(), "length": int(size)}).Error("bin unmarshalling int32")
+				logger.Default().Error("bin unmarshalling int32", "type", consts.UnmarshallingError, "data_length", buf.Len(), "length", int(size))
 				return fmt.Errorf(`wrong input data`)
 			}
 			for ; i < size; i++ {
 				byteVal, err := buf.ReadByte()
 				if err != nil {
-					log.WithFields(log.Fields{"type": consts.IOError, "error": err}).Error("reading bytes from buffer")
+					logger.Default().Error("reading bytes from buffer", "type", consts.IOError, "error", err)
 					return err
 				}
 				tmp[4-size+i] = byteVal
@@ -326,7 +326,7 @@ func BinUnmarshalBuff(buf *bytes.Buffer, v any) error {
 			return err
 		}
 		if buf.Len() < val {
-			log.WithFields(log.Fields{"type": consts.UnmarshallingError, "data_length": buf.Len(), "length": val}).Error("bin unmarshalling string")
+			logger.Default().Error("bin unmarshalling string", "type", consts.UnmarshallingError, "data_length", buf.Len(), "length", val)
 			return fmt.Errorf(`input slice is short`)
 		}
 		t.SetString(string(buf.Next(val)))
@@ -343,13 +343,13 @@ func BinUnmarshalBuff(buf *bytes.Buffer, v any) error {
 			return err
 		}
 		if buf.Len() < val {
-			log.WithFields(log.Fields{"type": consts.UnmarshallingError, "data_length": buf.Len(), "length": val}).Error("bin unmarshalling slice")
+			logger.Default().Error("bin unmarshalling slice", "type", consts.UnmarshallingError, "data_length", buf.Len(), "length", val)
 			return fmt.Errorf(`input slice is short`)
 		}
 		t.SetBytes(buf.Next(val))
 
 	default:
-		log.WithFields(log.Fields{"type": consts.UnmarshallingError, "value_type": t.Kind()}).Error("BinUnmrashal unsupported type")
+		logger.Default().Error("BinUnmrashal unsupported type", "type", consts.UnmarshallingError, "value_type", t.Kind())
 		return fmt.Errorf(`unsupported type of BinUnmarshal %v`, t.Kind())
 	}
 	return nil
@@ -409,7 +409,7 @@ func BinUnmarshal(out *[]byte, v any) error {
 			return err
 		}
 		if len(*out) < int(val) {
-			log.WithFields(log.Fields{"type": consts.UnmarshallingError, "data_length": len(*out), "length": int(val)}).Error("input slice is short")
+			logger.Default().Error("input slice is short", "type", consts.UnmarshallingError, "data_length", len(*out), "length", int(val))
 			return fmt.Errorf(`input slice is short`)
 		}
 		t.SetString(string((*out)[:val]))
@@ -431,7 +431,7 @@ func BinUnmarshal(out *[]byte, v any) error {
 		t.SetBytes((*out)[:val])
 		*out = (*out)[val:]
 	default:
-		log.WithFields(log.Fields{"type": consts.UnmarshallingError, "value_type": t.Kind()}).Error("BinUnmrashal unsupported type")
+		logger.Default().Error("BinUnmrashal unsupported type", "type", consts.UnmarshallingError, "value_type", t.Kind())
 		return fmt.Errorf(`unsupported type of BinUnmarshal %v`, t.Kind())
 	}
 	return nil
@@ -563,8 +563,7 @@ func HexToBin(ihexdata any) []byte {
 	var str []byte
 	str, err := hex.DecodeString(hexdata)
 	if err != nil {
-		log.WithFields(log.Fields{"data": hexdata, "error": err, "type": consts.ConversionError}).Error("decoding string to hex")
-		log.Printf("HexToBin error: %s", err)
+		logger.Default().Error("decoding string to hex", "data", hexdata, "error", err, "type", consts.ConversionError)
 	}
 	return str
 }
@@ -616,7 +615,7 @@ func InterfaceToStr(v any) (string, error) {
 		if reflect.TypeOf(v).String() == `map[string]interface {}` ||
 			reflect.TypeOf(v).String() == `*types.Map` {
 			if out, err := json.Marshal(v); err != nil {
-				log.WithFields(log.Fields{"error": err, "type": consts.JSONMarshallError}).Error("marshalling map for jsonb")
+				logger.Default().Error("marshalling map for jsonb", "error", err, "type", consts.JSONMarshallError)
 				return ``, err
 			} else {
 				str = string(out)
@@ -972,8 +971,7 @@ func ValueToInt(v any) (ret int64, err error) {
 		err = fmt.Errorf(`%v is not a valid integer`, val)
 	}
 	if err != nil {
-		log.WithFields(log.Fields{"type": consts.ConversionError, "error": err,
-			"value": fmt.Sprint(v)}).Error("converting value to int")
+		logger.Default().Error("converting value to int", "type", consts.ConversionError, "error", err, "value", fmt.Sprint(v))
 	}
 	return
 }
@@ -985,7 +983,7 @@ func ValueToDecimal(v any) (ret decimal.Decimal, err error) {
 	case string:
 		ret, err = decimal.NewFromString(val)
 		if err != nil {
-			log.WithFields(log.Fields{"type": consts.ConversionError, "error": err, "value": val}).Error("converting value from string to decimal")
+			logger.Default().Error("converting value from string to decimal", "type", consts.ConversionError, "error", err, "value", val)
 		} else {
 			ret = ret.Floor()
 		}
@@ -1010,7 +1008,7 @@ func Int64Toint(dat int64) (int, error) {
 func MarshalJson(v any) string {
 	buff, err := json.Marshal(v)
 	if err != nil {
-		log.WithFields(log.Fields{"v": v, "error": err}).Error("marshalJson error")
+		logger.Default().Error("marshalJson error", "v", v, "error", err)
 	}
 	return string(buff)
 }
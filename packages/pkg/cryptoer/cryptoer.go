@@ -0,0 +1,134 @@
+package cryptoer
+
+import (
+	"crypto/sha512"
+	"fmt"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/converter"
+	"github.com/IBAX-io/go-ibax/packages/common/crypto"
+	"strconv"
+)
+
+// Provider is the asymmetric and hash algorithm of a chain, used to derive the keys, hash and sign
+type Provider interface {
+	// Sign signs the hash of data
+	Sign(privateKey, data []byte) ([]byte, error)
+	// SignHash signs hash as it is, hash is the Hash of the signed data
+	SignHash(privateKey, hash []byte) ([]byte, error)
+	// Verify checks the signature of the hash of data
+	Verify(publicKey, data, signature []byte) (bool, error)
+	PrivateToPublic(privateKey []byte) ([]byte, error)
+	GenKeyPair() (privateKey, publicKey []byte, err error)
+	Hash(data []byte) []byte
+	DoubleHash(data []byte) []byte
+	// Address returns the key id of publicKey
+	Address(publicKey []byte) int64
+}
+
+// provider holds its own algorithms, independent of the process-global ones of go-ibax
+type provider struct {
+	asym crypto.AsymProvider
+	hash crypto.HashProvider
+}
+
+// New returns the Provider of the cryptoer and hasher names, such as ECC_Secp256k1 and KECCAK256
+func New(cryptoer, hasher string) (p Provider, err error) {
+	asym, ok := crypto.AsymAlgo_value[cryptoer]
+	if !ok {
+		return nil, fmt.Errorf("cryptoer %q is not supported", cryptoer)
+	}
+	hash, ok := crypto.HashAlgo_value[hasher]
+	if !ok {
+		return nil, fmt.Errorf("hasher %q is not supported", hasher)
+	}
+	// go-ibax panics on the algorithms it declares but does not implement
+	defer func() {
+		if r := recover(); r != nil {
+			p, err = nil, fmt.Errorf("%v", r)
+		}
+	}()
+	return &provider{asym: crypto.NewAsymAlgo(crypto.AsymAlgo(asym)), hash: crypto.NewHashAlgo(crypto.HashAlgo(hash))}, nil
+}
+
+func (p *provider) Sign(privateKey, data []byte) ([]byte, error) {
+	return p.asym.Sign(privateKey, p.hash.GetHash(data))
+}
+
+func (p *provider) SignHash(privateKey, hash []byte) ([]byte, error) {
+	return p.asym.Sign(privateKey, hash)
+}
+
+func (p *provider) Verify(publicKey, data, signature []byte) (bool, error) {
+	return p.asym.Verify(publicKey, p.hash.GetHash(data), signature)
+}
+
+func (p *provider) PrivateToPublic(privateKey []byte) ([]byte, error) {
+	return p.asym.PrivateToPublic(privateKey)
+}
+
+func (p *provider) GenKeyPair() ([]byte, []byte, error) {
+	return p.asym.GenKeyPair()
+}
+
+func (p *provider) Hash(data []byte) []byte {
+	return p.hash.GetHash(data)
+}
+
+func (p *provider) DoubleHash(data []byte) []byte {
+	return p.hash.DoubleHash(data)
+}
+
+// Address is crypto.Address with the hash of p
+func (p *provider) Address(publicKey []byte) int64 {
+	h := p.hash.GetHash(crypto.CutPub(publicKey))
+	h512 := sha512.Sum512(h)
+	crc := crypto.CalcChecksum(h512[:])
+	val := crypto.RepeatPrefixed(strconv.FormatUint(crc, 10))
+	sum := converter.CheckSum(val[:len(val)-1])
+	return int64(crc - (crc % 10) + uint64(sum))
+}
+
+// Global uses the process-global algorithms set by crypto.InitAsymAlgo and crypto.InitHashAlgo, it is used by the
+// functions without a Provider argument
+var Global Provider = global{}
+
+type global struct{}
+
+func (global) Sign(privateKey, data []byte) ([]byte, error) {
+	return crypto.Sign(privateKey, data)
+}
+
+func (global) SignHash(privateKey, hash []byte) ([]byte, error) {
+	return crypto.GetAsymProvider().Sign(privateKey, hash)
+}
+
+func (global) Verify(publicKey, data, signature []byte) (bool, error) {
+	return crypto.Verify(publicKey, data, signature)
+}
+
+func (global) PrivateToPublic(privateKey []byte) ([]byte, error) {
+	return crypto.PrivateToPublic(privateKey)
+}
+
+func (global) GenKeyPair() ([]byte, []byte, error) {
+	return crypto.GenKeyPair()
+}
+
+func (global) Hash(data []byte) []byte {
+	return crypto.Hash(data)
+}
+
+func (global) DoubleHash(data []byte) []byte {
+	return crypto.DoubleHash(data)
+}
+
+func (global) Address(publicKey []byte) int64 {
+	return crypto.Address(publicKey)
+}
+
+// OrGlobal returns p, Global if p is nil
+func OrGlobal(p Provider) Provider {
+	if p == nil {
+		return Global
+	}
+	return p
+}
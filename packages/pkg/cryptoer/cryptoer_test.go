@@ -0,0 +1,60 @@
+package cryptoer
+
+import (
+	"bytes"
+	"github.com/IBAX-io/go-ibax/packages/common/crypto"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	for _, v := range [][2]string{{"RSA", "KECCAK256"}, {"ECC_Secp256k1", "MD5"}, {"ECC_P512", "SHA256"}} {
+		if _, err := New(v[0], v[1]); err == nil {
+			t.Errorf("expected %s/%s to fail", v[0], v[1])
+		}
+	}
+}
+
+func TestProvider(t *testing.T) {
+	secp, err := New("ECC_Secp256k1", "KECCAK256")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sm, err := New("SM2", "SM3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("LOGIN1uid")
+	for _, p := range []Provider{secp, sm} {
+		key, pub, err := p.GenKeyPair()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if derived, err := p.PrivateToPublic(key); err != nil || !bytes.Equal(derived, pub) {
+			t.Errorf("want the public key of the pair, got %x %v", derived, err)
+		}
+		sign, err := p.Sign(key, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok, err := p.Verify(pub, data, sign); !ok || err != nil {
+			t.Errorf("expected the signature to be verified, got %v", err)
+		}
+	}
+	if bytes.Equal(secp.Hash(data), sm.Hash(data)) {
+		t.Error("expected the hashes of KECCAK256 and SM3 to differ")
+	}
+
+	// Address matches the one of go-ibax with the same global algorithms
+	crypto.InitAsymAlgo("ECC_Secp256k1")
+	crypto.InitHashAlgo("KECCAK256")
+	_, pub, err := secp.GenKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := secp.Address(pub), crypto.Address(pub); got != want {
+		t.Errorf("want address %d, got %d", want, got)
+	}
+	if !bytes.Equal(Global.DoubleHash(data), secp.DoubleHash(data)) {
+		t.Error("expected Global to use the global hash")
+	}
+}
@@ -5,19 +5,27 @@
 package transaction
 
 import (
+	"context"
+	"github.com/IBAX-io/go-ibax-sdk/packages/logger"
 	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/consts"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/cryptoer"
 	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/smart"
 	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/types"
-	log "github.com/sirupsen/logrus"
+	"github.com/IBAX-io/go-ibax-sdk/packages/signer"
 )
 
-func newTransaction(smartTx types.SmartTransaction, privateKey []byte, internal bool) (data, hash []byte, err error) {
+// The transactions are built with the process-global algorithms and logger unless they are given,
+// the clients give theirs by NewSignedTransactionWithLogger
+
+func newTransaction(ctx context.Context, p cryptoer.Provider, l logger.Logger, smartTx types.SmartTransaction, s signer.Signer, internal bool) (data, hash []byte, err error) {
 	stp := &SmartTransactionParser{
 		SmartContract: &smart.SmartContract{TxSmart: new(types.SmartTransaction)},
+		crypto:        p,
+		logger:        l,
 	}
-	data, err = stp.BinMarshalWithPrivate(&smartTx, privateKey, internal)
+	data, err = stp.BinMarshalWithSigner(ctx, &smartTx, s, internal)
 	if err != nil {
-		log.WithFields(log.Fields{"type": consts.MarshallingError, "error": err}).Error("marshalling smart contract to msgpack")
+		stp.log().Error("marshalling smart contract to msgpack", "type", consts.MarshallingError, "error", err)
 		return
 	}
 	hash = stp.Hash
@@ -26,9 +34,24 @@ func newTransaction(smartTx types.SmartTransaction, privateKey []byte, internal
 }
 
 func NewInternalTransaction(smartTx types.SmartTransaction, privateKey []byte) (data, hash []byte, err error) {
-	return newTransaction(smartTx, privateKey, true)
+	return newTransaction(context.Background(), cryptoer.Global, nil, smartTx, signer.Key(cryptoer.Global, privateKey), true)
 }
 
 func NewTransactionInProc(smartTx types.SmartTransaction, privateKey []byte) (data, hash []byte, err error) {
-	return newTransaction(smartTx, privateKey, false)
+	return newTransaction(context.Background(), cryptoer.Global, nil, smartTx, signer.Key(cryptoer.Global, privateKey), false)
+}
+
+// NewTransaction is NewTransactionInProc with the algorithms of p instead of the process-global ones
+func NewTransaction(p cryptoer.Provider, smartTx types.SmartTransaction, privateKey []byte) (data, hash []byte, err error) {
+	return newTransaction(context.Background(), p, nil, smartTx, signer.Key(p, privateKey), false)
+}
+
+// NewSignedTransaction is NewTransaction signed by s, the private key may be kept by another process
+func NewSignedTransaction(ctx context.Context, p cryptoer.Provider, smartTx types.SmartTransaction, s signer.Signer) (data, hash []byte, err error) {
+	return newTransaction(ctx, p, nil, smartTx, s, false)
+}
+
+// NewSignedTransactionWithLogger is NewSignedTransaction logging its failures to l instead of logger.Default()
+func NewSignedTransactionWithLogger(ctx context.Context, p cryptoer.Provider, l logger.Logger, smartTx types.SmartTransaction, s signer.Signer) (data, hash []byte, err error) {
+	return newTransaction(ctx, p, l, smartTx, s, false)
 }
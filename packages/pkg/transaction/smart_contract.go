@@ -5,21 +5,44 @@
 package transaction
 
 import (
+	"context"
+	"github.com/IBAX-io/go-ibax-sdk/packages/logger"
 	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/consts"
 	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/converter"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/cryptoer"
 	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/smart"
 	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/types"
 	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/utils"
+	"github.com/IBAX-io/go-ibax-sdk/packages/signer"
 	"github.com/IBAX-io/go-ibax/packages/common/crypto"
 	"github.com/vmihailenco/msgpack/v5"
 	"time"
 
 	"github.com/shopspring/decimal"
-	log "github.com/sirupsen/logrus"
 )
 
 type SmartTransactionParser struct {
 	*smart.SmartContract
+	crypto cryptoer.Provider // algorithms of the transaction, the process-global ones if nil
+	logger logger.Logger     // logger of the failures, logger.Default() if nil
+}
+
+// NewParser returns a parser validating the decoded transactions with the algorithms of p,
+// it logs to logger.Default()
+func NewParser(p cryptoer.Provider) *SmartTransactionParser {
+	return NewParserWithLogger(p, nil)
+}
+
+// NewParserWithLogger is NewParser logging to l
+func NewParserWithLogger(p cryptoer.Provider, l logger.Logger) *SmartTransactionParser {
+	return &SmartTransactionParser{SmartContract: &smart.SmartContract{}, crypto: p, logger: l}
+}
+
+func (s *SmartTransactionParser) log() logger.Logger {
+	if s.logger == nil {
+		return logger.Default()
+	}
+	return s.logger
 }
 
 func (s *SmartTransactionParser) txType() byte      { return s.TxSmart.TxType() }
@@ -39,7 +62,7 @@ func (s *SmartTransactionParser) Validate() error {
 	if err := s.TxSmart.Validate(); err != nil {
 		return err
 	}
-	_, err := utils.CheckSign([][]byte{crypto.CutPub(s.TxSmart.PublicKey)}, s.Hash, s.TxSignature, false)
+	_, err := utils.CheckSignWith(cryptoer.OrGlobal(s.crypto), [][]byte{crypto.CutPub(s.TxSmart.PublicKey)}, s.Hash, s.TxSignature, false)
 	if err != nil {
 		return err
 	}
@@ -59,10 +82,10 @@ func (s *SmartTransactionParser) Marshal() ([]byte, error) {
 	return buf, nil
 }
 
-func (s *SmartTransactionParser) setSig(privateKey []byte) error {
-	signature, err := crypto.Sign(privateKey, s.Hash)
+func (s *SmartTransactionParser) setSig(ctx context.Context, sg signer.Signer) error {
+	signature, err := sg.Sign(ctx, cryptoer.OrGlobal(s.crypto).Hash(s.Hash))
 	if err != nil {
-		log.WithFields(log.Fields{"type": consts.CryptoError, "error": err}).Error("signing by node private key")
+		s.log().Error("signing by node private key", "type", consts.CryptoError, "error", err)
 		return err
 	}
 	s.TxSignature = converter.EncodeLengthPlusData(signature)
@@ -70,21 +93,29 @@ func (s *SmartTransactionParser) setSig(privateKey []byte) error {
 }
 
 func (s *SmartTransactionParser) BinMarshalWithPrivate(smartTx *types.SmartTransaction, privateKey []byte, internal bool) ([]byte, error) {
+	return s.BinMarshalWithSigner(context.Background(), smartTx, signer.Key(cryptoer.OrGlobal(s.crypto), privateKey), internal)
+}
+
+// BinMarshalWithSigner is BinMarshalWithPrivate signed by sg, the private key may be kept by another process
+func (s *SmartTransactionParser) BinMarshalWithSigner(ctx context.Context, smartTx *types.SmartTransaction, sg signer.Signer, internal bool) ([]byte, error) {
 	var (
-		buf []byte
-		err error
+		buf, publicKey []byte
+		err            error
 	)
-	if err = smartTx.WithPrivate(privateKey, internal); err != nil {
+	p := cryptoer.OrGlobal(s.crypto)
+	if publicKey, err = sg.PublicKey(ctx); err != nil {
+		s.log().Error("getting the public key of the signer", "type", consts.CryptoError, "error", err)
 		return nil, err
 	}
+	smartTx.WithPublicKey(p, publicKey, internal)
 	s.TxSmart = smartTx
 	buf, err = s.TxSmart.Marshal()
 	if err != nil {
 		return nil, err
 	}
 	s.Payload = buf
-	s.Hash = crypto.DoubleHash(s.Payload)
-	err = s.setSig(privateKey)
+	s.Hash = p.DoubleHash(s.Payload)
+	err = s.setSig(ctx, sg)
 	if err != nil {
 		return nil, err
 	}
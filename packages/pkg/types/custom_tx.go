@@ -6,13 +6,14 @@ package types
 
 import (
 	"fmt"
+	"github.com/IBAX-io/go-ibax-sdk/packages/logger"
 	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/consts"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/cryptoer"
 	"github.com/IBAX-io/go-ibax/packages/common/crypto"
 	"regexp"
 	"strings"
 
 	"github.com/shopspring/decimal"
-	log "github.com/sirupsen/logrus"
 	"github.com/vmihailenco/msgpack/v5"
 )
 
@@ -73,19 +74,30 @@ func (s *SmartTransaction) TxType() byte {
 }
 
 func (s *SmartTransaction) WithPrivate(privateKey []byte, internal bool) error {
+	return s.WithPrivateKey(cryptoer.Global, privateKey, internal)
+}
+
+// WithPrivateKey sets the public key, and the signer if internal, of privateKey with the algorithms of p,
+// a failure is logged to logger.Default()
+func (s *SmartTransaction) WithPrivateKey(p cryptoer.Provider, privateKey []byte, internal bool) error {
 	var (
 		publicKey []byte
 		err       error
 	)
-	if publicKey, err = crypto.PrivateToPublic(privateKey); err != nil {
-		log.WithFields(log.Fields{"type": consts.CryptoError, "error": err}).Error("converting node private key to public")
+	if publicKey, err = p.PrivateToPublic(privateKey); err != nil {
+		logger.Default().Error("converting node private key to public", "type", consts.CryptoError, "error", err)
 		return err
 	}
+	s.WithPublicKey(p, publicKey, internal)
+	return nil
+}
+
+// WithPublicKey sets the public key, and the signer if internal, with the algorithms of p
+func (s *SmartTransaction) WithPublicKey(p cryptoer.Provider, publicKey []byte, internal bool) {
 	s.PublicKey = publicKey
 	if internal {
-		s.SignedBy = crypto.Address(publicKey)
+		s.SignedBy = p.Address(publicKey)
 	}
-	return nil
 }
 
 func (s *SmartTransaction) Unmarshal(buffer []byte) error {
@@ -1,34 +1,41 @@
 package utils
 
 import (
+	"context"
 	"fmt"
+	"github.com/IBAX-io/go-ibax-sdk/packages/logger"
 	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/consts"
 	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/converter"
-	"github.com/IBAX-io/go-ibax/packages/common/crypto"
-	log "github.com/sirupsen/logrus"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/cryptoer"
 	"path/filepath"
 	"runtime"
+	"time"
 )
 
-// CheckSign checks the signature
+// CheckSign checks the signature with the process-global algorithms
 func CheckSign(publicKeys [][]byte, forSign []byte, signs []byte, nodeKeyOrLogin bool) (bool, error) {
+	return CheckSignWith(cryptoer.Global, publicKeys, forSign, signs, nodeKeyOrLogin)
+}
+
+// CheckSignWith checks the signature with the algorithms of p
+func CheckSignWith(p cryptoer.Provider, publicKeys [][]byte, forSign []byte, signs []byte, nodeKeyOrLogin bool) (bool, error) {
 	defer func() {
 		if r := recover(); r != nil {
-			log.WithFields(log.Fields{"type": consts.PanicRecoveredError, "error": r}).Error("recovered panic in check sign")
+			logger.Default().Error("recovered panic in check sign", "type", consts.PanicRecoveredError, "error", r)
 		}
 	}()
 
 	var signsSlice [][]byte
 	if len(forSign) == 0 {
-		log.WithFields(log.Fields{"type": consts.EmptyObject}).Error("for sign is empty")
+		logger.Default().Error("for sign is empty", "type", consts.EmptyObject)
 		return false, ErrInfoFmt("len(forSign) == 0")
 	}
 	if len(publicKeys) == 0 {
-		log.WithFields(log.Fields{"type": consts.EmptyObject}).Error("public keys is empty")
+		logger.Default().Error("public keys is empty", "type", consts.EmptyObject)
 		return false, ErrInfoFmt("len(publicKeys) == 0")
 	}
 	if len(signs) == 0 {
-		log.WithFields(log.Fields{"type": consts.EmptyObject}).Error("signs is empty")
+		logger.Default().Error("signs is empty", "type", consts.EmptyObject)
 		return false, ErrInfoFmt("len(signs) == 0")
 	}
 
@@ -38,7 +45,7 @@ func CheckSign(publicKeys [][]byte, forSign []byte, signs []byte, nodeKeyOrLogin
 	} else {
 		length, err := converter.DecodeLength(&signs)
 		if err != nil {
-			log.WithFields(log.Fields{"type": consts.UnmarshallingError, "error": err}).Error("decoding signs length")
+			logger.Default().Error("decoding signs length", "type", consts.UnmarshallingError, "error", err)
 			return false, err
 		}
 		if length > 0 {
@@ -46,12 +53,12 @@ func CheckSign(publicKeys [][]byte, forSign []byte, signs []byte, nodeKeyOrLogin
 		}
 
 		if len(publicKeys) != len(signsSlice) {
-			log.WithFields(log.Fields{"public_keys_length": len(publicKeys), "signs_length": len(signsSlice), "type": consts.SizeDoesNotMatch}).Error("public keys and signs slices lengths does not match")
+			logger.Default().Error("public keys and signs slices lengths does not match", "public_keys_length", len(publicKeys), "signs_length", len(signsSlice), "type", consts.SizeDoesNotMatch)
 			return false, fmt.Errorf("sign error publicKeys length %d != signsSlice length %d", len(publicKeys), len(signsSlice))
 		}
 	}
 
-	return crypto.Verify(publicKeys[0], forSign, signsSlice[0])
+	return p.Verify(publicKeys[0], forSign, signsSlice[0])
 }
 
 // ErrInfoFmt fomats the error message
@@ -67,3 +74,16 @@ func Caller(steps int) string {
 	}
 	return name
 }
+
+// Sleep pauses the current goroutine for at least the duration d,
+// it returns ctx.Err() if ctx is done before d elapses
+func Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
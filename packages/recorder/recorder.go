@@ -0,0 +1,365 @@
+package recorder
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/IBAX-io/go-ibax-sdk/packages/logger"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Mode of a Recorder
+type Mode int
+
+const (
+	// ModeRecord sends the requests to the node and records the exchanges
+	ModeRecord Mode = iota
+	// ModeReplay serves the recorded responses without a node
+	ModeReplay
+)
+
+// Redacted replaces the tokens and signatures in the fixtures
+const Redacted = logger.Redacted
+
+// ErrNoInteraction is returned in replay mode when no recorded response is left for a request
+var ErrNoInteraction = errors.New("recorder: no recorded interaction")
+
+// Interaction is a recorded request and its response
+type Interaction struct {
+	Method     string            `json:"method"`
+	Path       string            `json:"path"`
+	RPCMethod  string            `json:"rpc_method,omitempty"`
+	Request    string            `json:"request,omitempty"`
+	TxHashes   []string          `json:"tx_hashes,omitempty"`
+	Status     int               `json:"status"`
+	Header     map[string]string `json:"header,omitempty"`
+	Body       string            `json:"body,omitempty"`
+	BodyBase64 string            `json:"body_base64,omitempty"`
+}
+
+type fixture struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Recorder is a http.RoundTripper that records the exchanges with a node into a fixture file,
+// or replays them. Use it with transport.WithRoundTripper.
+//
+// In replay mode the requests are matched by http method, url path and JSON-RPC method,
+// in the recorded order, so the requests signed again by the replayed flow still match.
+// The transaction hashes of sendTx, also in a batch, are mapped to the recorded ones by their position
+// in the request, so the transactions sent together must be sent in the recorded order. The JSON-RPC ids
+// of the responses are set to the ids of the requests
+type Recorder struct {
+	lock   sync.Mutex
+	path   string
+	mode   Mode
+	next   http.RoundTripper
+	list   []*Interaction
+	used   map[string]int    // replayed interactions by key
+	hashes map[string]string // recorded tx hash by replayed tx hash
+}
+
+// New creates a Recorder of the fixture file at path, in replay mode the file is loaded
+func New(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{
+		path:   path,
+		mode:   mode,
+		next:   http.DefaultTransport,
+		used:   make(map[string]int),
+		hashes: make(map[string]string),
+	}
+	if mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var f fixture
+		if err = json.Unmarshal(data, &f); err != nil {
+			return nil, fmt.Errorf("recorder: fixture %s invalid:%s", path, err.Error())
+		}
+		r.list = f.Interactions
+	}
+	return r, nil
+}
+
+// SetTransport sets the round tripper used to record, the default is http.DefaultTransport
+func (r *Recorder) SetTransport(rt http.RoundTripper) {
+	r.next = rt
+}
+
+// Save writes the recorded interactions to the fixture file
+func (r *Recorder) Save() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	data, err := json.MarshalIndent(&fixture{Interactions: r.list}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, data, 0644)
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	if r.mode == ModeReplay {
+		return r.replay(req, body)
+	}
+	return r.record(req, body)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+
+	it := &Interaction{
+		Method:    req.Method,
+		Path:      req.URL.RequestURI(),
+		RPCMethod: rpcMethod(body),
+		Request:   redactRequest(req, body),
+		TxHashes:  txHashes(req, body),
+		Status:    resp.StatusCode,
+		Header:    make(map[string]string),
+	}
+	for _, key := range []string{"Content-Type", "Content-Disposition"} {
+		if v := resp.Header.Get(key); v != "" {
+			it.Header[key] = v
+		}
+	}
+	if utf8.Valid(data) {
+		it.Body = string(logger.RedactJSON(data))
+	} else {
+		it.BodyBase64 = base64.StdEncoding.EncodeToString(data)
+	}
+	r.lock.Lock()
+	r.list = append(r.list, it)
+	r.lock.Unlock()
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	path := r.recordedHashes(req.URL.RequestURI())
+	method := rpcMethod(body)
+	key := req.Method + " " + path + " " + method
+	var it *Interaction
+	skip := r.used[key]
+	for _, v := range r.list {
+		if v.Method == req.Method && v.Path == path && v.RPCMethod == method {
+			if skip == 0 {
+				it = v
+				break
+			}
+			skip--
+		}
+	}
+	if it == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoInteraction, key)
+	}
+	r.used[key]++
+
+	for k, hash := range txHashes(req, body) {
+		if k < len(it.TxHashes) {
+			r.hashes[hash] = it.TxHashes[k]
+		}
+	}
+	var data []byte
+	if it.BodyBase64 != "" {
+		var err error
+		if data, err = base64.StdEncoding.DecodeString(it.BodyBase64); err != nil {
+			return nil, err
+		}
+	} else {
+		data = []byte(it.Body)
+		for hash, recorded := range r.hashes {
+			data = bytes.ReplaceAll(data, []byte(recorded), []byte(hash))
+		}
+		data = replaceIDs(it.Request, body, data)
+	}
+	resp := &http.Response{
+		Status:        fmt.Sprintf("%d %s", it.Status, http.StatusText(it.Status)),
+		StatusCode:    it.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        make(http.Header),
+		Body:          io.NopCloser(bytes.NewReader(data)),
+		ContentLength: int64(len(data)),
+		Request:       req,
+	}
+	for k, v := range it.Header {
+		resp.Header.Set(k, v)
+	}
+	return resp, nil
+}
+
+// recordedHashes replaces the replayed tx hashes in path by the recorded ones
+func (r *Recorder) recordedHashes(path string) string {
+	for hash, recorded := range r.hashes {
+		path = strings.ReplaceAll(path, hash, recorded)
+	}
+	return path
+}
+
+type rpcRequest struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+// rpcMethod returns the JSON-RPC method of body, the methods of a batch joined by comma
+func rpcMethod(body []byte) string {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return ""
+	}
+	if body[0] == '[' {
+		var list []rpcRequest
+		if json.Unmarshal(body, &list) != nil {
+			return ""
+		}
+		methods := make([]string, len(list))
+		for k, v := range list {
+			methods[k] = v.Method
+		}
+		return strings.Join(methods, ",")
+	}
+	var req rpcRequest
+	if body[0] != '{' || json.Unmarshal(body, &req) != nil {
+		return ""
+	}
+	return req.Method
+}
+
+// txHashes returns the hashes of the transactions sent by the request, in their order in the request.
+// The hashes of the sendTx requests of a batch follow the order of the batch
+func txHashes(req *http.Request, body []byte) []string {
+	var hashes []string
+	if mediaType, params, err := mime.ParseMediaType(req.Header.Get("Content-Type")); err == nil && mediaType == "multipart/form-data" {
+		mr := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+		for {
+			part, err := mr.NextPart()
+			if err != nil {
+				break
+			}
+			if name := part.FormName(); name != "" {
+				hashes = append(hashes, name)
+			}
+		}
+		return hashes
+	}
+	body = bytes.TrimSpace(body)
+	var list []rpcRequest
+	if len(body) > 0 && body[0] == '[' {
+		if json.Unmarshal(body, &list) != nil {
+			return nil
+		}
+	} else {
+		var rr rpcRequest
+		if len(body) == 0 || body[0] != '{' || json.Unmarshal(body, &rr) != nil {
+			return nil
+		}
+		list = append(list, rr)
+	}
+	for _, v := range list {
+		if v.Method == "ibax.sendTx" && len(v.Params) > 0 {
+			hashes = append(hashes, objectKeys(v.Params[0])...)
+		}
+	}
+	return hashes
+}
+
+// objectKeys returns the keys of the JSON object data in their order
+func objectKeys(data json.RawMessage) []string {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if t, err := dec.Token(); err != nil || t != json.Delim('{') {
+		return nil
+	}
+	var keys []string
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return nil
+		}
+		key, _ := t.(string)
+		var v json.RawMessage
+		if dec.Decode(&v) != nil {
+			return nil
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// redactRequest returns the request body for reference, without tokens, signatures and transaction data
+func redactRequest(req *http.Request, body []byte) string {
+	return logger.RedactBody(req.Header.Get("Content-Type"), body)
+}
+
+// replaceIDs sets the JSON-RPC ids of the response data to the ids of the request body,
+// the ids of a batch are mapped by the position of the requests in the recorded request
+func replaceIDs(recorded string, body, data []byte) []byte {
+	body, data = bytes.TrimSpace(body), bytes.TrimSpace(data)
+	if len(body) == 0 || len(data) == 0 || body[0] != data[0] {
+		return data
+	}
+	if body[0] == '{' {
+		var req rpcRequest
+		var resp map[string]json.RawMessage
+		if json.Unmarshal(body, &req) != nil || req.ID == nil || json.Unmarshal(data, &resp) != nil {
+			return data
+		}
+		resp["id"] = req.ID
+		out, err := json.Marshal(resp)
+		if err != nil {
+			return data
+		}
+		return out
+	}
+	var reqs, recordedReqs []rpcRequest
+	var resps []map[string]json.RawMessage
+	if json.Unmarshal(body, &reqs) != nil || json.Unmarshal([]byte(recorded), &recordedReqs) != nil ||
+		len(reqs) != len(recordedReqs) || json.Unmarshal(data, &resps) != nil {
+		return data
+	}
+	ids := make(map[string]json.RawMessage, len(reqs))
+	for k, v := range recordedReqs {
+		ids[string(v.ID)] = reqs[k].ID
+	}
+	for _, v := range resps {
+		if id, ok := ids[string(v["id"])]; ok {
+			v["id"] = id
+		}
+	}
+	out, err := json.Marshal(resps)
+	if err != nil {
+		return data
+	}
+	return out
+}
@@ -0,0 +1,143 @@
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// rpcNode answers login with a token, and txStatus with the block of the hash, also in a batch
+func rpcNode() *httptest.Server {
+	blocks := map[string]string{"aaaa": "7", "cccc": "8", "dddd": "9"}
+	type rpcReq struct {
+		ID     uint64            `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	answer := func(req rpcReq) string {
+		switch req.Method {
+		case "ibax.login":
+			return fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":{"token":"secret-jwt","key_id":"1"}}`, req.ID)
+		case "ibax.sendTx":
+			var txs map[string]string
+			json.Unmarshal(req.Params[0], &txs)
+			for hash := range txs {
+				return fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":{"hashes":{"%s":"%s"}}}`, req.ID, hash, hash)
+			}
+		case "ibax.txStatus":
+			var hash string
+			json.Unmarshal(req.Params[0], &hash)
+			return fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":{"%s":{"blockid":"%s"}}}`, req.ID, hash, blocks[hash])
+		}
+		return ""
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		if bytes.HasPrefix(body, []byte("[")) {
+			var list []rpcReq
+			json.Unmarshal(body, &list)
+			ret := make([]string, len(list))
+			for k, v := range list {
+				ret[k] = answer(v)
+			}
+			fmt.Fprintf(w, "[%s]", strings.Join(ret, ","))
+			return
+		}
+		var req rpcReq
+		json.Unmarshal(body, &req)
+		fmt.Fprint(w, answer(req))
+	}))
+}
+
+// batch sends a batch of sendTx, one request per hash
+func batch(t *testing.T, c *http.Client, url string, id int, hashes ...string) string {
+	list := make([]map[string]any, len(hashes))
+	for k, hash := range hashes {
+		list[k] = map[string]any{"jsonrpc": "2.0", "id": id + k, "method": "ibax.sendTx",
+			"params": []any{map[string]string{hash: "signed-tx"}}}
+	}
+	body, _ := json.Marshal(list)
+	resp, err := c.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("batch: %s", err)
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	return string(data)
+}
+
+func call(t *testing.T, c *http.Client, url string, id int, method string, params ...any) string {
+	body, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": id, "method": method, "params": params})
+	resp, err := c.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("%s: %s", method, err)
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	return string(data)
+}
+
+func TestRecorder(t *testing.T) {
+	node := rpcNode()
+	path := filepath.Join(t.TempDir(), "fixture.json")
+
+	rec, err := New(path, ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &http.Client{Transport: rec}
+	call(t, c, node.URL, 1, "ibax.login", map[string]string{"signature": "3045aa"})
+	call(t, c, node.URL, 2, "ibax.sendTx", map[string]string{"aaaa": "signed-tx"})
+	call(t, c, node.URL, 3, "ibax.txStatus", "aaaa")
+	batch(t, c, node.URL, 4, "cccc", "dddd")
+	call(t, c, node.URL, 6, "ibax.txStatus", "cccc")
+	call(t, c, node.URL, 7, "ibax.txStatus", "dddd")
+	if err = rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+	node.Close()
+
+	data, _ := readFile(path)
+	for _, secret := range []string{"secret-jwt", "3045aa", "signed-tx"} {
+		if strings.Contains(data, secret) {
+			t.Errorf("fixture contains %q", secret)
+		}
+	}
+
+	rec, err = New(path, ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c = &http.Client{Transport: rec}
+	if ret := call(t, c, node.URL, 11, "ibax.login", map[string]string{"signature": "3045bb"}); !strings.Contains(ret, `"id":11`) {
+		t.Errorf("want the response id of the request, got %s", ret)
+	}
+	call(t, c, node.URL, 12, "ibax.sendTx", map[string]string{"bbbb": "signed-again"})
+	if ret := call(t, c, node.URL, 13, "ibax.txStatus", "bbbb"); !strings.Contains(ret, `"bbbb"`) {
+		t.Errorf("want the status of the replayed hash, got %s", ret)
+	}
+	// the hashes of a batch are mapped by their position, not by their order
+	batch(t, c, node.URL, 14, "ffff", "eeee")
+	for k, hash := range []string{"ffff", "eeee"} {
+		want := fmt.Sprintf(`"%s":{"blockid":"%d"}`, hash, k+8)
+		if ret := call(t, c, node.URL, 16+k, "ibax.txStatus", hash); !strings.Contains(ret, want) {
+			t.Errorf("want %s, got %s", want, ret)
+		}
+	}
+	if _, err = c.Post(node.URL, "application/json", strings.NewReader(`{"id":18,"method":"ibax.txStatus"}`)); err == nil {
+		t.Errorf("want ErrNoInteraction when the recorded responses are used up")
+	}
+}
+
+func readFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	return string(data), err
+}
@@ -1,5 +1,11 @@
 package response
 
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+)
+
 type GetUIDResult struct {
 	UID         string `json:"uid,omitempty"`
 	Token       string `json:"token,omitempty"`
@@ -13,19 +19,20 @@ type GetUIDResult struct {
 }
 
 type LoginResult struct {
-	Token       string        `json:"token"`
-	EcosystemID string        `json:"ecosystem_id"`
-	KeyID       string        `json:"key_id"`
-	Account     string        `json:"account"`
-	NotifyKey   string        `json:"notify_key"`
-	IsNode      bool          `json:"isnode"`
-	IsOwner     bool          `json:"isowner"`
-	IsCLB       bool          `json:"clb"`
-	Timestamp   string        `json:"timestamp"`
-	Roles       []rolesResult `json:"roles"`
+	Token       string      `json:"token"`
+	EcosystemID string      `json:"ecosystem_id"`
+	KeyID       string      `json:"key_id"`
+	Account     string      `json:"account"`
+	NotifyKey   string      `json:"notify_key"`
+	IsNode      bool        `json:"isnode"`
+	IsOwner     bool        `json:"isowner"`
+	IsCLB       bool        `json:"clb"`
+	Timestamp   string      `json:"timestamp"`
+	Roles       []LoginRole `json:"roles"`
 }
 
-type rolesResult struct {
+// LoginRole is a role of the logged in key in the ecosystem
+type LoginRole struct {
 	RoleId   int64  `json:"role_id"`
 	RoleName string `json:"role_name"`
 }
@@ -34,3 +41,27 @@ type AuthStatusResponse struct {
 	IsActive  bool  `json:"active"`
 	ExpiresAt int64 `json:"exp,omitempty"`
 }
+
+// TokenExpireTime returns the exp claim of a JWT token as unix seconds, zero if token is not a JWT or has no exp.
+// The signature is not verified, the expiry is only used to log in again in time
+func TokenExpireTime(token string) int64 {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return 0
+	}
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return 0
+	}
+	var claims struct {
+		Exp json.Number `json:"exp"`
+	}
+	if err = json.Unmarshal(data, &claims); err != nil {
+		return 0
+	}
+	exp, err := claims.Exp.Float64()
+	if err != nil {
+		return 0
+	}
+	return int64(exp)
+}
@@ -0,0 +1,20 @@
+package response
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func TestTokenExpireTime(t *testing.T) {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"uid":"1","exp":1700000000}`))
+	for token, want := range map[string]int64{
+		"eyJhbGciOiJIUzI1NiJ9." + payload + ".c2lnbg":                                                     1700000000,
+		"eyJhbGciOiJIUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(`{"uid":"1"}`)) + ".c2lnbg": 0,
+		"4f0c7a9e8d6b5a4c3b2a1f0e":                                                                        0,
+		"a.!!.c":                                                                                          0,
+	} {
+		if got := TokenExpireTime(token); got != want {
+			t.Errorf("token %s: want %d, got %d", token, want, got)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package response
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/IBAX-io/go-ibax-sdk/packages/request"
+	"os"
+	"strings"
+)
+
+// DecodeBody decodes the body of a RESTful api response with status 200 into result by its content type,
+// a *request.FileType result is saved to its file name, or set to its value if the name is empty
+func DecodeBody(contentType string, data []byte, result any) error {
+	if result == nil {
+		return nil
+	}
+	var isFileType bool
+	switch result.(type) {
+	case *request.FileType:
+		isFileType = true
+	}
+	if strings.Contains(contentType, "application/json") && !isFileType {
+		err := json.Unmarshal(data, result)
+		if err != nil {
+			return fmt.Errorf("json unmarshal failed:%s", err.Error())
+		}
+		return nil
+	}
+	switch vt := result.(type) {
+	case *string:
+		*vt = string(data)
+	case *[]byte:
+		*vt = data
+	case *interface{}:
+		*vt = interface{}(data)
+	case *request.FileType:
+		if vt.Name != "" {
+			err := os.WriteFile(vt.Name, data, 0644)
+			if err != nil {
+				return err
+			}
+			*vt = request.FileType{Name: vt.Name, Type: contentType}
+		} else {
+			*vt = request.FileType{Type: contentType, Value: string(data)}
+		}
+	default:
+		return fmt.Errorf("not supported TYPE:%T", vt)
+	}
+	return nil
+}
@@ -1,15 +1,148 @@
 package response
 
-import "errors"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
 
 var NotSupportError = errors.New("not support")
 
-type jsonError struct {
+// The sentinel errors below are matched by errors.Is against the errors of both backends
+var (
+	ErrUnauthorized    = errors.New("unauthorized")
+	ErrNotFound        = errors.New("not found")
+	ErrInvalidParams   = errors.New("invalid params")
+	ErrContractPenalty = errors.New("contract penalty")
+)
+
+// JSON-RPC error codes of the node
+const (
+	RPCCodeDefault             = -32000
+	RPCCodeInvalidInput        = -32001
+	RPCCodeResourceNotFound    = -32002
+	RPCCodeResourceUnavailable = -32003
+	RPCCodeTransactionRejected = -32004
+	RPCCodeMethodNotSupported  = -32005
+	RPCCodeLimitExceeded       = -32006
+	RPCCodeParseError          = -32007
+	RPCCodeInvalidRequest      = -32008
+	RPCCodeMethodNotFound      = -32009
+	RPCCodeInvalidParams       = -32010
+	RPCCodeInternalError       = -32011
+	RPCCodeNotFound            = -32012
+	RPCCodeUnknownUID          = -32013
+	RPCCodeUnauthorized        = -32014
+	RPCCodeParamsInvalid       = -32015
+)
+
+// RPCError is the error object of a JSON-RPC response
+type RPCError struct {
 	Code    int            `json:"code"`
 	Message string         `json:"message"`
 	Data    map[string]any `json:"data,omitempty"`
 }
 
-func (e *jsonError) Error() string {
+func (e *RPCError) Error() string {
 	return e.Message
 }
+
+func (e *RPCError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.Code == RPCCodeUnauthorized
+	case ErrNotFound:
+		return e.Code == RPCCodeResourceNotFound || e.Code == RPCCodeNotFound ||
+			e.Code == RPCCodeDefault && strings.Contains(e.Message, "has not been found")
+	case ErrInvalidParams:
+		return e.Code == RPCCodeInvalidInput || e.Code == RPCCodeInvalidParams || e.Code == RPCCodeParamsInvalid
+	}
+	return false
+}
+
+// HTTPError is a response of the RESTful api, or of the JSON-RPC endpoint, with a status other than 200.
+// Code and Message are the error and msg fields of the RESTful api error body
+type HTTPError struct {
+	StatusCode int
+	Body       string
+	Code       string
+	Message    string
+}
+
+// NewHTTPError creates a HTTPError from the response status and body
+func NewHTTPError(statusCode int, body []byte) *HTTPError {
+	e := &HTTPError{StatusCode: statusCode, Body: strings.TrimSpace(string(body))}
+	var ret struct {
+		Error string `json:"error"`
+		Msg   string `json:"msg"`
+	}
+	if json.Unmarshal(body, &ret) == nil {
+		e.Code = ret.Error
+		e.Message = ret.Msg
+	}
+	return e
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf(`%d %s`, e.StatusCode, e.Body)
+}
+
+func (e *HTTPError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		switch e.Code {
+		case "E_UNAUTHORIZED", "E_TOKENEXPIRED", "E_TOKEN":
+			return true
+		case "":
+			return e.StatusCode == http.StatusUnauthorized
+		}
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound || e.Code == "E_HASHNOTFOUND"
+	case ErrInvalidParams:
+		switch e.Code {
+		case "E_UNDEFINEVAL", "E_INVALIDWALLET", "E_PARAMMONEYDIGIT", "E_HASHWRONG":
+			return true
+		}
+	}
+	return false
+}
+
+// TxPenaltyError is the error of a transaction rejected by the node or penalized in a block,
+// Type and Message are the fields of the txstatus errmsg
+type TxPenaltyError struct {
+	Hash    string `json:"-"`
+	BlockId int64  `json:"-"`
+	Penalty int64  `json:"-"`
+	Type    string `json:"type"`
+	Message string `json:"error"`
+}
+
+func (e *TxPenaltyError) Error() string {
+	if e.Type == "" {
+		return e.Message
+	}
+	return e.Type + ": " + e.Message
+}
+
+func (e *TxPenaltyError) Is(target error) bool {
+	return target == ErrContractPenalty
+}
+
+// UnsupportedError is returned without sending a call the node does not support, it matches NotSupportError
+type UnsupportedError struct {
+	Backend string // rest or rpc
+	Method  string // url path of the RESTful api or JSON-RPC method name
+}
+
+func (e *UnsupportedError) Error() string {
+	if e.Backend == "rest" {
+		return fmt.Sprintf("the node does not serve the RESTful api %s", e.Method)
+	}
+	return fmt.Sprintf("the node does not support the JSON-RPC method %s", e.Method)
+}
+
+func (e *UnsupportedError) Is(target error) bool {
+	return target == NotSupportError
+}
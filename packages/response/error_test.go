@@ -0,0 +1,50 @@
+package response
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestError_Is(t *testing.T) {
+	cases := []struct {
+		err    error
+		target error
+		want   bool
+	}{
+		{NewHTTPError(401, []byte(`{"error":"E_TOKENEXPIRED","msg":"Token is expired by 1m"}`)), ErrUnauthorized, true},
+		{NewHTTPError(401, []byte(`{"error":"E_PERMISSION","msg":"Permission denied"}`)), ErrUnauthorized, false},
+		{NewHTTPError(401, nil), ErrUnauthorized, true},
+		{&RPCError{Code: RPCCodeUnauthorized, Message: "Unauthorized"}, ErrUnauthorized, true},
+		{NewHTTPError(400, []byte(`{"error":"E_HASHNOTFOUND","msg":"Hash 01 has not been found"}`)), ErrNotFound, true},
+		{&RPCError{Code: RPCCodeDefault, Message: "hash 01 has not been found"}, ErrNotFound, true},
+		{&RPCError{Code: RPCCodeNotFound, Message: "not found"}, ErrNotFound, true},
+		{NewHTTPError(400, []byte(`{"error":"E_UNDEFINEVAL","msg":"Value name is undefined"}`)), ErrInvalidParams, true},
+		{&RPCError{Code: RPCCodeInvalidParams, Message: "params name invalid"}, ErrInvalidParams, true},
+		{&RPCError{Code: RPCCodeInvalidParams, Message: "params name invalid"}, ErrNotFound, false},
+		{fmt.Errorf("call failed:%w", &TxPenaltyError{Type: "panic", Message: "division by zero"}), ErrContractPenalty, true},
+	}
+	for i, c := range cases {
+		if got := errors.Is(c.err, c.target); got != c.want {
+			t.Errorf("case %d: errors.Is(%v, %v) = %v, want %v", i, c.err, c.target, got, c.want)
+		}
+	}
+
+	var httpErr *HTTPError
+	if err := error(NewHTTPError(404, []byte(`{"error":"E_NOTFOUND","msg":"Page not found"}`))); !errors.As(err, &httpErr) || httpErr.Code != "E_NOTFOUND" || httpErr.Message != "Page not found" {
+		t.Errorf("want the error and msg of the body, got %+v", httpErr)
+	}
+}
+
+func TestTxStatusResult_Failure(t *testing.T) {
+	r := TxStatusResult{Hash: "01", BlockId: 10, Err: "hello"}
+	if err := r.Failure(); err != nil {
+		t.Errorf("the result of a successful contract is not a failure, got %v", err)
+	}
+
+	r = TxStatusResult{Hash: "01", BlockId: 10, Penalty: 1, Err: `{"type":"panic","error":"division by zero"}`}
+	var penalty *TxPenaltyError
+	if !errors.As(r.Failure(), &penalty) || penalty.Type != "panic" || penalty.Message != "division by zero" || penalty.Hash != "01" {
+		t.Errorf("want the penalty of the transaction, got %+v", penalty)
+	}
+}
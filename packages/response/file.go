@@ -0,0 +1,43 @@
+package response
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+)
+
+// FileStream streams a file response into Writer instead of buffering it,
+// the content type, length and hash are set after the response is read
+type FileStream struct {
+	Writer        io.Writer // destination of the file
+	Hash          hash.Hash // hashes the file on the fly, sha256 if nil
+	ContentType   string    // Content-Type of the response
+	ContentLength int64     // Content-Length of the response, -1 if unknown
+	Written       int64     // bytes written into Writer
+}
+
+// NewFileStream creates a FileStream writing into w and hashing by h, sha256 if h is nil
+func NewFileStream(w io.Writer, h hash.Hash) *FileStream {
+	return &FileStream{Writer: w, Hash: h}
+}
+
+// Copy writes body into Writer and updates the hash
+func (f *FileStream) Copy(contentType string, contentLength int64, body io.Reader) error {
+	if f.Hash == nil {
+		f.Hash = sha256.New()
+	}
+	f.Hash.Reset()
+	f.ContentType, f.ContentLength = contentType, contentLength
+	var err error
+	f.Written, err = io.Copy(io.MultiWriter(f.Writer, f.Hash), body)
+	return err
+}
+
+// Sum returns the hex hash of the written bytes
+func (f *FileStream) Sum() string {
+	if f.Hash == nil {
+		return ""
+	}
+	return hex.EncodeToString(f.Hash.Sum(nil))
+}
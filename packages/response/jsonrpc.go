@@ -2,6 +2,7 @@ package response
 
 import (
 	"encoding/json"
+	"errors"
 	"github.com/IBAX-io/go-ibax-sdk/packages/request"
 )
 
@@ -11,11 +12,35 @@ type Response struct {
 	JSONRPC string          `json:"jsonrpc"`
 	ID      request.ID      `json:"id"`
 	Result  json.RawMessage `json:"result,omitempty"`
-	Error   *jsonError      `json:"error,omitempty"`
+	Error   *RPCError       `json:"error,omitempty"`
 }
 
 type BatchResponse []Response
 
+// SetResults unmarshals the results of r into the requests with the same id, the errors of the calls are set to their requests
+func (r BatchResponse) SetResults(reqs []request.BatchRequest) error {
+	if len(r) == 0 {
+		return errors.New("not result in JSON-RPC response")
+	}
+	for _, v := range r {
+		for k, b := range reqs {
+			if b.Req.ID != v.ID {
+				continue
+			}
+			if v.Error != nil {
+				b.Err = v.Error
+			} else if len(v.Result) == 0 {
+				b.Err = errors.New("not result in JSON-RPC response")
+			} else {
+				b.Err = json.Unmarshal(v.Result, &b.Result)
+			}
+			reqs[k] = b
+			break
+		}
+	}
+	return nil
+}
+
 // MarshalJSON implements json.Marshaler and adds the "jsonrpc":"2.0"
 // property.
 func (r Response) MarshalJSON() ([]byte, error) {
@@ -1,26 +1,48 @@
 package response
 
-type roleInfo struct {
+import "strconv"
+
+// RoleInfo is a role of a key in an ecosystem
+type RoleInfo struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
 }
 
-type notifyInfo struct {
+// RoleID returns the numeric ID used by Login, zero if it is malformed
+func (r RoleInfo) RoleID() int64 {
+	id, _ := strconv.ParseInt(r.ID, 10, 64)
+	return id
+}
+
+// NotifyInfo is the number of notifications of a role
+type NotifyInfo struct {
 	RoleID string `json:"role_id"`
 	Count  int64  `json:"count"`
 }
 
-type keyEcosystemInfo struct {
+// KeyEcosystemInfo is an ecosystem of a key, with the roles of the key in it
+type KeyEcosystemInfo struct {
 	Ecosystem     string       `json:"ecosystem"`
 	Name          string       `json:"name"`
 	Digits        int64        `json:"digits"`
-	Roles         []roleInfo   `json:"roles,omitempty"`
-	Notifications []notifyInfo `json:"notifications,omitempty"`
+	Roles         []RoleInfo   `json:"roles,omitempty"`
+	Notifications []NotifyInfo `json:"notifications,omitempty"`
 }
 
 type KeyInfoResult struct {
 	Account    string              `json:"account"`
-	Ecosystems []*keyEcosystemInfo `json:"ecosystems"`
+	Ecosystems []*KeyEcosystemInfo `json:"ecosystems"`
+}
+
+// Ecosystem returns the ecosystem of the key, nil if the key is not a member of it
+func (k *KeyInfoResult) Ecosystem(ecosystem int64) *KeyEcosystemInfo {
+	id := strconv.FormatInt(ecosystem, 10)
+	for _, v := range k.Ecosystems {
+		if v != nil && v.Ecosystem == id {
+			return v
+		}
+	}
+	return nil
 }
 
 type TokenBalanceResult struct {
@@ -1,5 +1,7 @@
 package response
 
+import "encoding/json"
+
 type SendTxResult struct {
 	Hashes map[string]string `json:"hashes"`
 }
@@ -10,3 +12,16 @@ type TxStatusResult struct {
 	Penalty int64  `json:"penalty"`
 	Err     string `json:"err"`
 }
+
+// Failure returns the *TxPenaltyError of a transaction rejected by the node or penalized in a block,
+// nil if the transaction succeeded or its status is unknown, Err is the contract result then
+func (r *TxStatusResult) Failure() error {
+	if r.Err == "" || r.BlockId > 0 && r.Penalty == 0 {
+		return nil
+	}
+	e := &TxPenaltyError{Hash: r.Hash, BlockId: r.BlockId, Penalty: r.Penalty}
+	if json.Unmarshal([]byte(r.Err), e) != nil {
+		e.Type, e.Message = "", r.Err
+	}
+	return e
+}
@@ -1,12 +1,13 @@
 package auth
 
 import (
+	"context"
 	"encoding/hex"
 	"fmt"
 	"github.com/IBAX-io/go-ibax-sdk/packages/modus"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/cryptoer"
 	"github.com/IBAX-io/go-ibax-sdk/packages/request"
 	"github.com/IBAX-io/go-ibax-sdk/packages/response"
-	"github.com/IBAX-io/go-ibax/packages/common/crypto"
 	"strconv"
 	"time"
 )
@@ -19,25 +20,11 @@ func New(b modus.Base) modus.Authentication {
 	return &auth{base: b}
 }
 
-type loginResult struct {
-	Token       string        `json:"token,omitempty"`
-	EcosystemID string        `json:"ecosystem_id,omitempty"`
-	KeyID       string        `json:"key_id,omitempty"`
-	Account     string        `json:"account,omitempty"`
-	NotifyKey   string        `json:"notify_key,omitempty"`
-	IsNode      bool          `json:"isnode"`
-	IsOwner     bool          `json:"isowner"`
-	IsCLB       bool          `json:"clb"`
-	Timestamp   string        `json:"timestamp,omitempty"`
-	Roles       []rolesResult `json:"roles,omitempty"`
-}
-
-type rolesResult struct {
-	RoleId   int64  `json:"role_id"`
-	RoleName string `json:"role_name"`
+func (a *auth) GetUidResponse() (*response.GetUIDResult, error) {
+	return a.GetUidResponseContext(context.Background())
 }
 
-func (a *auth) GetUidResponse() (*response.GetUIDResult, error) {
+func (a *auth) GetUidResponseContext(ctx context.Context) (*response.GetUIDResult, error) {
 	var ret response.GetUIDResult
 
 	message := request.RequestParams{
@@ -48,7 +35,7 @@ func (a *auth) GetUidResponse() (*response.GetUIDResult, error) {
 	if err != nil {
 		return nil, err
 	}
-	err = a.base.GET(req, &ret)
+	err = a.base.GETContext(ctx, req, &ret)
 	if err != nil {
 		return nil, err
 	}
@@ -57,6 +44,10 @@ func (a *auth) GetUidResponse() (*response.GetUIDResult, error) {
 }
 
 func (a *auth) GetUid() error {
+	return a.GetUidContext(context.Background())
+}
+
+func (a *auth) GetUidContext(ctx context.Context) error {
 	var ret response.GetUIDResult
 
 	message := request.RequestParams{
@@ -67,7 +58,7 @@ func (a *auth) GetUid() error {
 	if err != nil {
 		return err
 	}
-	err = a.base.GET(req, &ret)
+	err = a.base.GETContext(ctx, req, &ret)
 	if err != nil {
 		return err
 	}
@@ -117,16 +108,26 @@ type loginForm struct {
 // Login
 // roleId : Get the role id through the keyinfo interface
 func (a *auth) Login(roleId int64) (err error) {
-	var (
-		sign []byte
-		ret  loginResult
-	)
+	return a.LoginContext(context.Background(), roleId)
+}
+
+func (a *auth) LoginContext(ctx context.Context, roleId int64) (err error) {
+	_, err = a.LoginResponseContext(ctx, roleId)
+	return
+}
+
+func (a *auth) LoginResponse(roleId int64) (*response.LoginResult, error) {
+	return a.LoginResponseContext(context.Background(), roleId)
+}
+
+func (a *auth) LoginResponseContext(ctx context.Context, roleId int64) (*response.LoginResult, error) {
+	var ret response.LoginResult
 
 	cnf := a.base.GetConfig()
 	nonceSalt := fmt.Sprintf("LOGIN%d", cnf.NetworkId)
-	sign, err = crypto.SignString(cnf.PrivateKey, nonceSalt+cnf.UID)
+	sign, err := a.base.Signer().Sign(ctx, cryptoer.OrGlobal(a.base.Crypto()).Hash([]byte(nonceSalt+cnf.UID)))
 	if err != nil {
-		return
+		return nil, err
 	}
 
 	form := &loginForm{}
@@ -137,26 +138,77 @@ func (a *auth) Login(roleId int64) (err error) {
 		form.RoleID = roleId
 	}
 
-	var req request.Request
 	message := request.RequestParams{
 		Namespace: request.NamespaceIBAX,
 		Name:      "login",
 		Params:    []any{form},
 	}
-	req, err = a.base.NewMessage(message)
+	req, err := a.base.NewMessage(message)
 	if err != nil {
-		return
+		return nil, err
 	}
 
-	err = a.base.GET(req, &ret)
-	if err == nil {
-		cnf.Token = ret.Token
-		a.base.SetConfig(cnf)
+	err = a.base.GETContext(ctx, req, &ret)
+	if err != nil {
+		return nil, err
 	}
-	return
+	cnf.Token = ret.Token
+	cnf.TokenExpireTime = response.TokenExpireTime(ret.Token)
+	//the role is kept for the next logins
+	cnf.RoleId = roleId
+	a.base.SetConfig(cnf)
+	return &ret, nil
+}
+
+func (a *auth) LoginAs(roleName string) (*response.LoginResult, error) {
+	return a.LoginAsContext(context.Background(), roleName)
+}
+
+func (a *auth) LoginAsContext(ctx context.Context, roleName string) (*response.LoginResult, error) {
+	roles, err := a.RolesContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range roles {
+		if v.Name == roleName {
+			return a.LoginResponseContext(ctx, v.RoleID())
+		}
+	}
+	return nil, fmt.Errorf("role %s %w in ecosystem %d", roleName, response.ErrNotFound, a.base.GetConfig().Ecosystem)
+}
+
+func (a *auth) Roles() ([]response.RoleInfo, error) {
+	return a.RolesContext(context.Background())
+}
+
+func (a *auth) RolesContext(ctx context.Context) ([]response.RoleInfo, error) {
+	var ret response.KeyInfoResult
+
+	cnf := a.base.GetConfig()
+	message := request.RequestParams{
+		Namespace: request.NamespaceIBAX,
+		Name:      "getKeyInfo",
+		Params:    []any{cnf.Account},
+	}
+	req, err := a.base.NewMessage(message)
+	if err != nil {
+		return nil, err
+	}
+	err = a.base.GETContext(ctx, req, &ret)
+	if err != nil {
+		return nil, err
+	}
+	if eco := ret.Ecosystem(cnf.Ecosystem); eco != nil {
+		return eco.Roles, nil
+	}
+	return nil, nil
 }
 
 func (a *auth) AutoLogin() (err error) {
+	return a.AutoLoginContext(context.Background())
+}
+
+func (a *auth) AutoLoginContext(ctx context.Context) (err error) {
 	cnf := a.base.GetConfig()
 	if cnf.Token != "" {
 		if time.Unix(cnf.TokenExpireTime, 0).Sub(time.Now()) < time.Minute*10 {
@@ -166,18 +218,22 @@ func (a *auth) AutoLogin() (err error) {
 			return nil
 		}
 	}
-	err = a.GetUid()
+	err = a.GetUidContext(ctx)
 	if err == nil {
-		//default 0
-		err = a.Login(0)
+		//default 0, the role of the config
+		err = a.LoginContext(ctx, cnf.RoleId)
 		if err == nil {
-			err = a.GetUid()
+			err = a.GetUidContext(ctx)
 		}
 	}
 	return
 }
 
 func (a *auth) GetAuthStatus() (*response.AuthStatusResponse, error) {
+	return a.GetAuthStatusContext(context.Background())
+}
+
+func (a *auth) GetAuthStatusContext(ctx context.Context) (*response.AuthStatusResponse, error) {
 	var result response.AuthStatusResponse
 
 	message := request.RequestParams{
@@ -189,7 +245,7 @@ func (a *auth) GetAuthStatus() (*response.AuthStatusResponse, error) {
 		return &result, err
 	}
 
-	err = a.base.GET(req, &result)
+	err = a.base.GETContext(ctx, req, &result)
 	if err != nil {
 		return &result, err
 	}
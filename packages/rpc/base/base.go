@@ -1,22 +1,25 @@
 package base
 
 import (
-	"bytes"
+	"context"
 	"encoding/hex"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"github.com/IBAX-io/go-ibax-sdk/config"
+	"github.com/IBAX-io/go-ibax-sdk/packages/keysource"
+	"github.com/IBAX-io/go-ibax-sdk/packages/logger"
+	"github.com/IBAX-io/go-ibax-sdk/packages/metrics"
 	"github.com/IBAX-io/go-ibax-sdk/packages/modus"
 	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/converter"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/cryptoer"
 	"github.com/IBAX-io/go-ibax-sdk/packages/request"
 	"github.com/IBAX-io/go-ibax-sdk/packages/response"
+	"github.com/IBAX-io/go-ibax-sdk/packages/signer"
+	"github.com/IBAX-io/go-ibax-sdk/packages/tracing"
+	"github.com/IBAX-io/go-ibax-sdk/packages/transport"
 	"github.com/IBAX-io/go-ibax/packages/common/crypto"
 	"github.com/shopspring/decimal"
-	"io"
-	"log"
 	"net/http"
-	"net/url"
 	"os"
 	"strings"
 	"sync"
@@ -51,7 +54,7 @@ func MustRequest(id uint64, jsonMethod string, params ...any) (request.Request,
 	return r, nil
 }
 
-// PrivateToPublicHex returns the hex public key for the specified hex private key.
+// PrivateToPublicHex returns the hex public key for the specified hex private key, with the process-global algorithms of go-ibax
 func PrivateToPublicHex(hexkey string) (string, error) {
 	key, err := hex.DecodeString(hexkey)
 	if err != nil {
@@ -64,94 +67,179 @@ func PrivateToPublicHex(hexkey string) (string, error) {
 	return crypto.PubToHex(pubKey), nil
 }
 
+// defaultTimeout is the request time limit used when no http client or timeout is configured
+const defaultTimeout = 10 * time.Second
+
 type base struct {
-	config *config.Config
-	lock   sync.RWMutex
-	id     uint64
+	config    *config.Config
+	lock      sync.RWMutex
+	id        *uint64 // JSON-RPC id counter, shared with the login copies
+	transport *transport.Transport
+	crypto    cryptoer.Provider
+	signer    signer.Signer
 }
 
 func New(config config.Config) modus.Base {
-	b := &base{config: &config}
-	if err := b.Init(); err != nil {
-		log.Fatalf("new base init failed:%s\n", err.Error())
+	b, err := NewWithOptions(config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "new base init failed:%s\n", err.Error())
+		os.Exit(1)
 	}
 	return b
 }
 
+// NewWithOptions returns the init error instead of exiting the process
+func NewWithOptions(config config.Config, opts ...transport.Option) (modus.Base, error) {
+	o := transport.NewOptions(opts...)
+	if o.HTTPClient == nil && o.Timeout == 0 {
+		o.Timeout = defaultTimeout
+	}
+	b := &base{config: &config, id: new(uint64), transport: transport.New(o)}
+	if err := b.Init(); err != nil {
+		return nil, err
+	}
+	b.transport.SetAddress(config.ApiAddress)
+	b.transport.KeepSession(b.tokenExpiry)
+	if len(config.ApiAddresses) > 0 {
+		b.transport.SetPool(transport.NewPool(config.Endpoints(), b.maxBlockId, o))
+	}
+	return b, nil
+}
+
+// maxBlockId is the health probe of the node endpoints
+func (c *base) maxBlockId(ctx context.Context, endpoint string) (int64, error) {
+	req, err := c.NewMessage(request.RequestParams{
+		Namespace: request.NamespaceIBAX,
+		Name:      "maxBlockId",
+	})
+	if err != nil {
+		return 0, err
+	}
+	var result int64
+	err = c.doRequest(ctx, endpoint, http.MethodGet, req, &result)
+	return result, err
+}
+
+// Pool returns the node endpoint pool, nil if api_addresses is not configured
+func (c *base) Pool() *transport.Pool {
+	return c.transport.Pool()
+}
+
+// Metrics returns the metrics set by transport.WithMetrics, metrics.Nop if not set
+func (c *base) Metrics() metrics.Metrics {
+	return c.transport.Metrics()
+}
+
+// Logger returns the logger set by transport.WithLogger, logger.Nop if not set
+func (c *base) Logger() logger.Logger {
+	return c.transport.Logger()
+}
+
+// Tracer returns the tracer set by transport.WithTracer, tracing.Nop if not set
+func (c *base) Tracer() tracing.Tracer {
+	return c.transport.Tracer()
+}
+
+// Crypto returns the algorithms of the cryptoer and hasher of the config, nil if they are not configured
+func (c *base) Crypto() cryptoer.Provider {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.crypto
+}
+
+// Signer returns the signer of the config, else the one of the private key of the config
+func (c *base) Signer() signer.Signer {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.signer
+}
+
+// key returns the private key of the current config
+func (c *base) key(ctx context.Context) ([]byte, error) {
+	return c.GetConfig().Key(ctx)
+}
+
 func (c *base) Init() (err error) {
-	var (
-		key, pub []byte
-		pubStr   string
-	)
+	var pub []byte
 
 	c.lock.Lock()
 	defer c.lock.Unlock()
-	crypto.InitAsymAlgo(c.config.Cryptoer)
-	crypto.InitHashAlgo(c.config.Hasher)
-	if c.config.PrivateKey == "" {
+	// the algorithms belong to the client, the process-global ones of go-ibax are not changed
+	c.crypto, c.signer = nil, nil
+	if c.config.Cryptoer != "" || c.config.Hasher != "" {
+		if c.crypto, err = cryptoer.New(c.config.Cryptoer, c.config.Hasher); err != nil {
+			return
+		}
+	}
+	// the key of a key source is read again at every signing, it is not kept
+	c.signer = signer.FromSource(c.crypto, keysource.Func(c.key))
+	if c.config.Signer != nil {
+		c.signer = c.config.Signer
+	}
+	if !c.config.CanSign() {
 		return nil
 	}
-	key = []byte(c.config.PrivateKey)
-	if len(key) > 64 {
-		key = key[:64]
+	if c.crypto == nil {
+		return fmt.Errorf("cryptoer and hasher are needed by the private key")
 	}
-	if len(key) != 64 {
-		return fmt.Errorf("private key invalid")
+	if len(c.config.PrivateKey) > 64 {
+		c.config.PrivateKey = c.config.PrivateKey[:64]
 	}
-	c.config.PrivateKey = string(key)
 
-	pubStr, err = PrivateToPublicHex(string(key))
-	if err != nil {
-		return fmt.Errorf("prase public key failed:%s", err.Error())
+	// the lock is held, the public key of the private key is derived from this config
+	pubSigner := c.config.Signer
+	if pubSigner == nil {
+		pubSigner = signer.FromSource(c.crypto, keysource.Func(c.config.Key))
 	}
-	pub, err = hex.DecodeString(pubStr)
+	pub, err = pubSigner.PublicKey(context.Background())
 	if err != nil {
-		return fmt.Errorf("invalid pubic key:%s", err.Error())
+		return fmt.Errorf("private key invalid:%s", err.Error())
 	}
 	c.config.PublicKey = pub
-	c.config.KeyId = crypto.Address(pub)
+	c.signer = signer.WithPublicKey(c.signer, pub)
+	c.config.KeyId = c.crypto.Address(pub)
 	c.config.Account = converter.AddressToString(c.config.KeyId)
 
 	return
 }
 
-func (c *base) sendRequest(method string, form any, result any) error {
-	return c.sendRawRequest(method, form, result)
+func (c *base) sendRequest(ctx context.Context, method string, form any, result any) error {
+	return c.sendRawRequest(ctx, method, form, result)
 }
 
 func (c *base) ExpediteValidator(expedite string) error {
 	//input Max unit expedite
 	if expedite == "" {
-		return errors.New("[expedite] is empty")
+		return fmt.Errorf("[expedite] is empty:%w", response.ErrInvalidParams)
 	}
 	d, err := decimal.NewFromString(expedite)
 	if err != nil {
-		return fmt.Errorf("[expedite] params invalid:%s,err:%s", expedite, err.Error())
+		return fmt.Errorf("[expedite] %w:%s,err:%s", response.ErrInvalidParams, expedite, err.Error())
 	}
 	mix := decimal.New(1, -12)
 	if d.LessThan(mix) || d.Mod(mix).GreaterThan(decimal.Zero) {
-		return fmt.Errorf("[expedite] inconsistent with the smallest reference unit and its integer multiples:%s", expedite)
+		return fmt.Errorf("[expedite] %w, inconsistent with the smallest reference unit and its integer multiples:%s", response.ErrInvalidParams, expedite)
 	}
 	return nil
 }
 
 func (c *base) AmountValidator(amount string) error {
 	if amount == "" {
-		return errors.New("[amount] is empty")
+		return fmt.Errorf("[amount] is empty:%w", response.ErrInvalidParams)
 	}
 	d, err := decimal.NewFromString(amount)
 	if err != nil {
-		return fmt.Errorf("[amount] params invalid:%s,err:%s", amount, err.Error())
+		return fmt.Errorf("[amount] %w:%s,err:%s", response.ErrInvalidParams, amount, err.Error())
 	}
 	mix := decimal.New(1, 0)
 	if d.LessThan(mix) || d.Mod(mix).GreaterThan(decimal.Zero) {
-		return fmt.Errorf("[amount] inconsistent with the smallest reference unit and its integer multiples:%s", amount)
+		return fmt.Errorf("[amount] %w, inconsistent with the smallest reference unit and its integer multiples:%s", response.ErrInvalidParams, amount)
 	}
 	return nil
 }
 
 func (c *base) nextID() uint64 {
-	return atomic.AddUint64(&c.id, 1)
+	return atomic.AddUint64(c.id, 1)
 }
 
 func (c *base) newMethod(namespace request.Namespace, name string) (method string) {
@@ -178,168 +266,123 @@ func (c *base) NewBatchMessage(requestPrams []request.BatchRequestParams) ([]req
 	return batchRequest, nil
 }
 
-var defaultClient = &http.Client{
-	Timeout: 10 * time.Second,
-}
-
-func (c *base) sendRawRequest(method string, msg any, result any) error {
-	var (
-		isBatch bool
-		body    []byte
-		err     error
-	)
-
-	if msg != nil {
-		switch msg.(type) {
-		case request.Request:
-		case []request.BatchRequest:
-			isBatch = true
-			reqs := msg.([]request.BatchRequest)
-			batchRequest := make([]request.Request, len(reqs))
-			for k, v := range reqs {
-				batchRequest[k] = *v.Req
-			}
-			body, err = json.Marshal(batchRequest)
-			if err != nil {
-				return err
-			}
-
-		default:
-			return fmt.Errorf("the request structure does not support")
-		}
-	}
-	if !isBatch {
-		body, err = json.Marshal(msg)
-		if err != nil {
-			return err
-		}
+func (c *base) sendRawRequest(ctx context.Context, method string, msg any, result any) error {
+	call := &transport.Call{Backend: transport.BackendRPC, Method: method, Params: msg, Result: result}
+	switch m := msg.(type) {
+	case request.Request:
+		call.Name, call.Params = m.Method, m.Params
+	case []request.BatchRequest:
+		call.Name = "batch"
 	}
+	return c.transport.Intercept(ctx, call, func(ctx context.Context, call *transport.Call) error {
+		return c.sendWithRetry(ctx, method, msg, result)
+	})
+}
 
-	cnf := c.GetConfig()
-	apiAddress := cnf.ApiAddress
-	req, err := http.NewRequest(method, apiAddress, bytes.NewReader(body))
+// sendWithRetry sends msg by the retry policy, sendTx is sent again only after txStatus confirms it is absent
+func (c *base) sendWithRetry(ctx context.Context, method string, msg any, result any) error {
+	return c.transport.RetryMessage(ctx, msg, func(endpoint string) error {
+		return c.doRequest(ctx, endpoint, method, msg, result)
+	}, func(endpoint string, hashes []string) (bool, error) {
+		return c.hashesAbsent(ctx, endpoint, hashes)
+	})
+}
+
+// hashesAbsent reports whether the node at endpoint has not found the transactions of hashes, it is only used for
+// a single one
+func (c *base) hashesAbsent(ctx context.Context, endpoint string, hashes []string) (bool, error) {
+	statusReq, err := c.NewMessage(request.RequestParams{
+		Namespace: request.NamespaceIBAX,
+		Name:      "txStatus",
+		Params:    []any{strings.Join(hashes, ",")},
+	})
 	if err != nil {
-		return err
+		return false, err
 	}
+	var result map[string]json.RawMessage
+	return transport.TxAbsent(c.doRequest(ctx, endpoint, http.MethodPost, statusReq, &result))
+}
 
-	req.ContentLength = int64(len(body))
+// doRequest sends msg to endpoint, or to the api address if endpoint is empty
+func (c *base) doRequest(ctx context.Context, endpoint, method string, msg any, result any) error {
+	cnf := c.GetConfig()
+	if endpoint == "" {
+		endpoint = cnf.ApiAddress
+	}
+	return c.transport.SendMessage(ctx, c.target(cnf, method, endpoint), msg, result)
+}
 
-	req.Header.Set("Content-Type", "application/json")
+// target returns the target of a request to url with the token of cnf, the token is dropped if the node rejects it
+func (c *base) target(cnf config.Config, method, url string) transport.Target {
+	target := transport.Target{Method: method, URL: url, Unauthorized: func() {
+		c.clearToken(cnf.Token)
+	}}
 	if len(cnf.Token) > 0 {
-		req.Header.Set("Authorization", cnf.JwtPrefix+cnf.Token)
-	}
-	resp, err := defaultClient.Do(req)
-	if err != nil {
-		return err
+		target.Authorization = cnf.JwtPrefix + cnf.Token
 	}
+	return target
+}
 
-	defer resp.Body.Close()
+// Close stops the session keeper and closes the websocket connections to the nodes
+func (c *base) Close() error {
+	return c.transport.Close()
+}
 
-	if resp.StatusCode != http.StatusOK {
-		if resp.StatusCode == http.StatusUnauthorized {
-			c.config.Token = ""
-		}
-		return fmt.Errorf(`status code:%d`, resp.StatusCode)
-	}
-	if result == nil {
-		return nil
-	}
+func (c *base) GET(form any, v any) error {
+	return c.GETContext(context.Background(), form, v)
+}
 
-	var isFileType bool
-	switch result.(type) {
-	case *request.FileType:
-		isFileType = true
-	}
-	contentType := resp.Header.Get("Content-Type")
-	if strings.Contains(contentType, "application/json") && !isFileType {
-		if isBatch {
-			ret := &response.BatchResponse{}
-			err = json.NewDecoder(resp.Body).Decode(ret)
-			if err != nil {
-				return fmt.Errorf("json response decode failed:%s", err.Error())
-			}
-			if len(*ret) == 0 {
-				return errors.New("not result in JSON-RPC response")
-			}
-
-			bq := msg.([]request.BatchRequest)
-			for _, v := range *ret {
-				for k, b := range bq {
-					if b.Req.ID == v.ID {
-						if v.Error != nil {
-							b.Err = v.Error
-							bq[k] = b
-							break
-						}
-						if len(v.Result) == 0 {
-							b.Err = errors.New("not result in JSON-RPC response")
-							bq[k] = b
-							break
-						}
-
-						err = json.Unmarshal(v.Result, &b.Result)
-						if err != nil {
-							b.Err = err
-							bq[k] = b
-							break
-						}
-						bq[k] = b
-						break
-					}
-				}
-			}
-			return nil
-		}
-		ret := &response.Response{}
-		err = json.NewDecoder(resp.Body).Decode(ret)
-		if err != nil {
-			return fmt.Errorf("json response decode failed:%s", err.Error())
-		}
+func (c *base) GETContext(ctx context.Context, form any, v any) error {
+	return c.sendRequest(ctx, http.MethodGet, form, v)
+}
 
-		if ret.Error != nil {
-			return ret.Error
-		}
-		if len(ret.Result) == 0 {
-			return errors.New("not result in JSON-RPC response")
-		}
+func (c *base) POST(form any, v any) error {
+	return c.POSTContext(context.Background(), form, v)
+}
 
-		err = json.Unmarshal(ret.Result, &result)
-		if err != nil {
-			return err
-		}
+func (c *base) POSTContext(ctx context.Context, form any, v any) error {
+	return c.sendRequest(ctx, http.MethodPost, form, v)
+}
+
+// clearToken drops the token rejected by the node unless it has been replaced already
+func (c *base) clearToken(token string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.config.Token == token {
+		c.config.Token = ""
 	}
-	if isFileType {
-		var data []byte
-		data, err = io.ReadAll(resp.Body)
-		if err != nil {
+}
+
+// SetReauth sets the login flow of transport.WithReauth and the session keeper. login runs on a copy of the base
+// without the token, so the requests sent meanwhile keep the current token. The token of the copy replaces it
+// only after the login succeeds
+func (c *base) SetReauth(login func(ctx context.Context, b modus.Base) error) {
+	c.transport.SetReauth(func(ctx context.Context) error {
+		lb := c.loginCopy()
+		if err := login(ctx, lb); err != nil {
 			return err
 		}
-		switch vt := result.(type) {
-		case *request.FileType:
-			fileName := result.(*request.FileType).Name
-			if fileName != "" {
-				err = os.WriteFile(fileName, data, 0644)
-				if err != nil {
-					return err
-				}
-				*vt = request.FileType{fileName, contentType, ""}
-			} else {
-				*vt = request.FileType{"", contentType, string(data)}
-			}
-		default:
-			return fmt.Errorf("response Content-Type not support:%s\n", contentType)
-		}
-	}
-
-	return nil
+		cnf := lb.GetConfig()
+		c.setToken(cnf.Token, cnf.TokenExpireTime)
+		return nil
+	})
 }
 
-func (c *base) GET(form any, v any) error {
-	return c.sendRequest(http.MethodGet, form, v)
+// loginCopy returns a base sharing the transport and the algorithms of c, with its own copy of the config without the token
+func (c *base) loginCopy() *base {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	cnf := *c.config
+	cnf.Token, cnf.TokenExpireTime = "", 0
+	return &base{config: &cnf, id: c.id, transport: c.transport, crypto: c.crypto, signer: c.signer}
 }
 
-func (c *base) POST(form any, v any) error {
-	return c.sendRequest(http.MethodPost, form, v)
+// setToken replaces the token and its expiry
+func (c *base) setToken(token string, expire int64) {
+	c.lock.Lock()
+	c.config.Token, c.config.TokenExpireTime = token, expire
+	c.lock.Unlock()
+	c.transport.TokenChanged()
 }
 
 func (c *base) GetConfig() config.Config {
@@ -350,20 +393,23 @@ func (c *base) GetConfig() config.Config {
 
 func (c *base) SetConfig(cnf config.Config) {
 	c.lock.Lock()
-	defer c.lock.Unlock()
+	changed := c.config.Token != cnf.Token || c.config.TokenExpireTime != cnf.TokenExpireTime
 	c.config = &cnf
+	c.lock.Unlock()
+	if changed {
+		c.transport.TokenChanged()
+	}
 }
 
-func (c *base) Version() string {
-	return config.Version
+// tokenExpiry returns the expiry of the token, zero if there is no token or its expiry is unknown
+func (c *base) tokenExpiry() time.Time {
+	cnf := c.GetConfig()
+	if cnf.Token == "" || cnf.TokenExpireTime == 0 {
+		return time.Time{}
+	}
+	return time.Unix(cnf.TokenExpireTime, 0)
 }
 
-func (c *base) SendGet(url string, form *url.Values, result any) error {
-	return response.NotSupportError
-}
-func (c *base) SendPost(url string, form *url.Values, result any) error {
-	return response.NotSupportError
-}
-func (c *base) SendMultipart(url string, files map[string][]byte, result any) error {
-	return response.NotSupportError
+func (c *base) Version() string {
+	return config.Version
 }
@@ -0,0 +1,80 @@
+package base
+
+import (
+	"context"
+	"github.com/IBAX-io/go-ibax-sdk/packages/transport"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// The node serves the RESTful api at the api path of the same address as the JSON-RPC api,
+// SendGet, SendPost and SendMultipart send the requests there
+
+// defaultApiPath is the RESTful api path used if api_path is not configured
+const defaultApiPath = "/api/v2/"
+
+// restAddress returns the RESTful api address of endpoint, or of the api address if endpoint is empty.
+// The websocket scheme is replaced by the http one
+func (c *base) restAddress(endpoint string) string {
+	cnf := c.GetConfig()
+	if endpoint == "" {
+		endpoint = cnf.ApiAddress
+	}
+	if strings.HasPrefix(endpoint, "ws") {
+		endpoint = "http" + strings.TrimPrefix(endpoint, "ws")
+	}
+	apiPath := cnf.ApiPath
+	if apiPath == "" {
+		apiPath = defaultApiPath
+	}
+	return strings.TrimSuffix(endpoint, "/") + "/" + strings.TrimPrefix(apiPath, "/")
+}
+
+func (c *base) SendGet(url string, form *url.Values, result any) error {
+	return c.SendGetContext(context.Background(), url, form, result)
+}
+
+func (c *base) SendGetContext(ctx context.Context, url string, form *url.Values, result any) error {
+	return c.sendRESTRequest(ctx, http.MethodGet, url, form, result)
+}
+
+func (c *base) SendPost(url string, form *url.Values, result any) error {
+	return c.SendPostContext(context.Background(), url, form, result)
+}
+
+func (c *base) SendPostContext(ctx context.Context, url string, form *url.Values, result any) error {
+	return c.sendRESTRequest(ctx, http.MethodPost, url, form, result)
+}
+
+func (c *base) sendRESTRequest(ctx context.Context, method, url string, form *url.Values, result any) error {
+	call := &transport.Call{Backend: transport.BackendREST, Method: method, Name: url, Params: form, Result: result}
+	return c.transport.Intercept(ctx, call, func(ctx context.Context, call *transport.Call) error {
+		return c.transport.Retry(ctx, func(endpoint string) error {
+			return c.transport.SendForm(ctx, c.restTarget(method, endpoint, url), form, result)
+		})
+	})
+}
+
+func (c *base) SendMultipart(url string, files map[string][]byte, result any) error {
+	return c.SendMultipartContext(context.Background(), url, files, result)
+}
+
+// SendMultipartContext
+// sendTx is sent again only after txStatus confirms the node has not received the transactions
+func (c *base) SendMultipartContext(ctx context.Context, url string, files map[string][]byte, result any) error {
+	call := &transport.Call{Backend: transport.BackendREST, Method: http.MethodPost, Name: url, Params: files, Result: result}
+	return c.transport.Intercept(ctx, call, func(ctx context.Context, call *transport.Call) error {
+		return c.transport.RetryFiles(ctx, url == "sendTx", files, func(endpoint string) error {
+			return c.transport.SendFiles(ctx, c.restTarget(http.MethodPost, endpoint, url), files, result)
+		}, func(endpoint string, hashes []string) (bool, error) {
+			return c.hashesAbsent(ctx, endpoint, hashes)
+		})
+	})
+}
+
+// restTarget returns the target of the request to url of the RESTful api of endpoint, or of the api address if
+// endpoint is empty
+func (c *base) restTarget(method, endpoint, url string) transport.Target {
+	return c.target(c.GetConfig(), method, c.restAddress(endpoint)+url)
+}
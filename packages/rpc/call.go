@@ -0,0 +1,120 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/IBAX-io/go-ibax-sdk/packages/modus"
+	"github.com/IBAX-io/go-ibax-sdk/packages/request"
+)
+
+var (
+	// ErrBatchNotExecuted is returned by Future.Get before the batch is executed
+	ErrBatchNotExecuted = errors.New("batch is not executed")
+	// ErrBatchExecuted is returned by Batch.Execute if the batch has been executed already
+	ErrBatchExecuted = errors.New("batch is executed already")
+	// ErrNoResult is returned when the node sent no result for a call
+	ErrNoResult = errors.New("not result in JSON-RPC response")
+)
+
+// Call sends the JSON-RPC method of namespace with params by c, and decodes the result into T.
+// It calls the methods of the node that the client does not wrap:
+//
+//	maxBlockId, err := rpc.Call[int64](ctx, c, request.NamespaceIBAX, "maxBlockId")
+func Call[T any](ctx context.Context, c modus.Base, namespace request.Namespace, method string, params ...any) (T, error) {
+	var result T
+	req, err := c.NewMessage(request.RequestParams{Namespace: namespace, Name: method, Params: params})
+	if err != nil {
+		return result, err
+	}
+	err = c.POSTContext(ctx, req, &result)
+	return result, err
+}
+
+// Batch queues JSON-RPC calls with Add and sends them in a single request by Execute
+type Batch struct {
+	c        modus.Base
+	params   []request.BatchRequestParams
+	results  []*batchResult
+	executed bool
+}
+
+type batchResult struct {
+	data json.RawMessage
+	err  error
+}
+
+// Future is the result of a call queued in a Batch, it is available after Batch.Execute
+type Future[T any] struct {
+	batch  *Batch
+	result *batchResult
+}
+
+// NewBatch creates a batch sent by c
+func NewBatch(c modus.Base) *Batch {
+	return &Batch{c: c}
+}
+
+// Add queues the JSON-RPC method of namespace with params in b, the result is decoded into T
+func Add[T any](b *Batch, namespace request.Namespace, method string, params ...any) *Future[T] {
+	r := &batchResult{}
+	b.params = append(b.params, request.BatchRequestParams{
+		RequestParams: request.RequestParams{Namespace: namespace, Name: method, Params: params},
+		Result:        &r.data,
+	})
+	b.results = append(b.results, r)
+	return &Future[T]{batch: b, result: r}
+}
+
+// Len returns the number of the queued calls
+func (b *Batch) Len() int {
+	return len(b.params)
+}
+
+// Execute sends the queued calls. The error is the one of the request, the errors of the calls are returned by their futures
+func (b *Batch) Execute(ctx context.Context) error {
+	if b.executed {
+		return ErrBatchExecuted
+	}
+	b.executed = true
+	if len(b.params) == 0 {
+		return nil
+	}
+	reqs, err := b.c.NewBatchMessage(b.params)
+	if err != nil {
+		return b.fail(err)
+	}
+	if err = b.c.POSTContext(ctx, reqs, b.params); err != nil {
+		return b.fail(err)
+	}
+	for k, v := range reqs {
+		r := b.results[k]
+		if v.Err != nil {
+			r.err = v.Err
+		} else if len(r.data) == 0 {
+			r.err = ErrNoResult
+		}
+	}
+	return nil
+}
+
+// fail sets err to all the calls
+func (b *Batch) fail(err error) error {
+	for _, r := range b.results {
+		r.err = err
+	}
+	return err
+}
+
+// Get returns the result of the call, or its error
+func (f *Future[T]) Get() (T, error) {
+	var result T
+	if !f.batch.executed {
+		return result, ErrBatchNotExecuted
+	}
+	if f.result.err != nil {
+		return result, f.result.err
+	}
+	err := json.Unmarshal(f.result.data, &result)
+	return result, err
+}
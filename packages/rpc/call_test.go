@@ -0,0 +1,72 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"github.com/IBAX-io/go-ibax-sdk/packages/client"
+	"github.com/IBAX-io/go-ibax-sdk/packages/ibaxtest"
+	"github.com/IBAX-io/go-ibax-sdk/packages/request"
+	"github.com/IBAX-io/go-ibax-sdk/packages/response"
+	"github.com/IBAX-io/go-ibax-sdk/packages/rpc"
+	"github.com/IBAX-io/go-ibax/packages/common/crypto"
+	"testing"
+)
+
+func TestCall(t *testing.T) {
+	node := ibaxtest.NewNode()
+	defer node.Close()
+	node.SetTable("members", map[string]string{"id": "1", "member_name": "founder"})
+	key, _, err := crypto.GenHexKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := client.NewClientWithOptions(node.Config(key, true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.AutoLogin(); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	node.SetBalance(1, c.GetConfig().Account, "100")
+
+	balance, err := rpc.Call[response.TokenBalanceResult](ctx, c, request.NamespaceIBAX, "getBalance", c.GetConfig().Account)
+	if err != nil || balance.Amount != "100" {
+		t.Errorf("unexpected balance %+v %v", balance, err)
+	}
+	if _, err = rpc.Call[string](ctx, c, request.NamespaceIBAX, "getRow", "members", 2); !errors.Is(err, response.ErrNotFound) {
+		t.Errorf("expected not found, got %v", err)
+	}
+
+	b := rpc.NewBatch(c)
+	maxBlockID := rpc.Add[int64](b, request.NamespaceIBAX, "maxBlockId")
+	row := rpc.Add[response.RowResult](b, request.NamespaceIBAX, "getRow", "members", 1)
+	missing := rpc.Add[response.RowResult](b, request.NamespaceIBAX, "getRow", "members", 2)
+	if _, err = row.Get(); err != rpc.ErrBatchNotExecuted {
+		t.Errorf("expected ErrBatchNotExecuted, got %v", err)
+	}
+	if err = b.Execute(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if id, err := maxBlockID.Get(); err != nil || id != node.MaxBlockID() {
+		t.Errorf("unexpected max block id %d %v", id, err)
+	}
+	if r, err := row.Get(); err != nil || r.Value["member_name"] != "founder" {
+		t.Errorf("unexpected row %+v %v", r, err)
+	}
+	if _, err = missing.Get(); !errors.Is(err, response.ErrNotFound) {
+		t.Errorf("expected not found, got %v", err)
+	}
+	if err = b.Execute(ctx); err != rpc.ErrBatchExecuted {
+		t.Errorf("expected ErrBatchExecuted, got %v", err)
+	}
+
+	rest, err := client.NewClientWithOptions(node.Config(key, false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the RESTful client sends JSON-RPC calls to the root path of the same node
+	if id, err := rpc.Call[int64](ctx, rest, request.NamespaceIBAX, "maxBlockId"); err != nil || id != node.MaxBlockID() {
+		t.Errorf("unexpected max block id %d %v", id, err)
+	}
+}
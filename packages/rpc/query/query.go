@@ -1,11 +1,13 @@
 package query
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/IBAX-io/go-ibax-sdk/packages/modus"
 	"github.com/IBAX-io/go-ibax-sdk/packages/request"
 	"github.com/IBAX-io/go-ibax-sdk/packages/response"
+	"github.com/IBAX-io/go-ibax-sdk/packages/tracing"
 	"strconv"
 )
 
@@ -18,6 +20,10 @@ func New(b modus.Base) modus.Query {
 }
 
 func (q *query) GetKeyInfo(account string) (*response.KeyInfoResult, error) {
+	return q.GetKeyInfoContext(context.Background(), account)
+}
+
+func (q *query) GetKeyInfoContext(ctx context.Context, account string) (*response.KeyInfoResult, error) {
 	var ret response.KeyInfoResult
 	message := request.RequestParams{
 		Namespace: request.NamespaceIBAX,
@@ -29,7 +35,7 @@ func (q *query) GetKeyInfo(account string) (*response.KeyInfoResult, error) {
 		return &ret, err
 	}
 
-	err = q.GET(req, &ret)
+	err = q.GETContext(ctx, req, &ret)
 	if err != nil {
 		return &ret, err
 	}
@@ -37,6 +43,10 @@ func (q *query) GetKeyInfo(account string) (*response.KeyInfoResult, error) {
 }
 
 func (q *query) EcosystemInfo(ecosystem int64) (*response.EcosystemInfo, error) {
+	return q.EcosystemInfoContext(context.Background(), ecosystem)
+}
+
+func (q *query) EcosystemInfoContext(ctx context.Context, ecosystem int64) (*response.EcosystemInfo, error) {
 	var result response.EcosystemInfo
 	message := request.RequestParams{
 		Namespace: request.NamespaceIBAX,
@@ -47,7 +57,7 @@ func (q *query) EcosystemInfo(ecosystem int64) (*response.EcosystemInfo, error)
 	if err != nil {
 		return &result, err
 	}
-	err = q.GET(req, &result)
+	err = q.GETContext(ctx, req, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -55,6 +65,10 @@ func (q *query) EcosystemInfo(ecosystem int64) (*response.EcosystemInfo, error)
 }
 
 func (q *query) EcosystemCount() (int64, error) {
+	return q.EcosystemCountContext(context.Background())
+}
+
+func (q *query) EcosystemCountContext(ctx context.Context) (int64, error) {
 	var result int64
 	message := request.RequestParams{
 		Namespace: request.NamespaceIBAX,
@@ -64,7 +78,7 @@ func (q *query) EcosystemCount() (int64, error) {
 	if err != nil {
 		return result, err
 	}
-	err = q.GET(req, &result)
+	err = q.GETContext(ctx, req, &result)
 	if err != nil {
 		return result, err
 	}
@@ -75,10 +89,14 @@ func (q *query) EcosystemCount() (int64, error) {
 // names Use‘,’ split multiple query parameters
 // names if is zero value: get all
 func (q *query) SystemParams(names string, params ...int) (*response.ParamsResult, error) {
+	return q.SystemParamsContext(context.Background(), names, params...)
+}
+
+func (q *query) SystemParamsContext(ctx context.Context, names string, params ...int) (*response.ParamsResult, error) {
 	var result response.ParamsResult
 	var offset, limit int
 	if len(params) > 2 {
-		return nil, errors.New("params invalid")
+		return nil, response.ErrInvalidParams
 	}
 	for k, v := range params {
 		if k == 0 {
@@ -97,7 +115,7 @@ func (q *query) SystemParams(names string, params ...int) (*response.ParamsResul
 		return &result, err
 	}
 
-	err = q.GET(req, &result)
+	err = q.GETContext(ctx, req, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -108,10 +126,14 @@ func (q *query) SystemParams(names string, params ...int) (*response.ParamsResul
 // names Use‘,’ split multiple query parameters
 // names if is zero value: get all
 func (q *query) EcosystemParams(ecosystem int64, names string, params ...int) (*response.ParamsResult, error) {
+	return q.EcosystemParamsContext(context.Background(), ecosystem, names, params...)
+}
+
+func (q *query) EcosystemParamsContext(ctx context.Context, ecosystem int64, names string, params ...int) (*response.ParamsResult, error) {
 	var result response.ParamsResult
 	var offset, limit int
 	if len(params) > 2 {
-		return nil, errors.New("params invalid")
+		return nil, response.ErrInvalidParams
 	}
 	for k, v := range params {
 		if k == 0 {
@@ -131,7 +153,7 @@ func (q *query) EcosystemParams(ecosystem int64, names string, params ...int) (*
 		return &result, err
 	}
 
-	err = q.GET(req, &result)
+	err = q.GETContext(ctx, req, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -139,6 +161,10 @@ func (q *query) EcosystemParams(ecosystem int64, names string, params ...int) (*
 }
 
 func (q *query) GetHistory(tableName string, tableId uint64) (*response.HistoryResult, error) {
+	return q.GetHistoryContext(context.Background(), tableName, tableId)
+}
+
+func (q *query) GetHistoryContext(ctx context.Context, tableName string, tableId uint64) (*response.HistoryResult, error) {
 	var result response.HistoryResult
 	message := request.RequestParams{
 		Namespace: request.NamespaceIBAX,
@@ -150,13 +176,17 @@ func (q *query) GetHistory(tableName string, tableId uint64) (*response.HistoryR
 		return &result, err
 	}
 
-	err = q.GET(req, &result)
+	err = q.GETContext(ctx, req, &result)
 	if err != nil {
 		return &result, err
 	}
 	return &result, nil
 }
 func (q *query) Balance(wallet string, ecosystemId int64) (*response.TokenBalanceResult, error) {
+	return q.BalanceContext(context.Background(), wallet, ecosystemId)
+}
+
+func (q *query) BalanceContext(ctx context.Context, wallet string, ecosystemId int64) (*response.TokenBalanceResult, error) {
 	var result response.TokenBalanceResult
 	message := request.RequestParams{
 		Namespace: request.NamespaceIBAX,
@@ -167,7 +197,7 @@ func (q *query) Balance(wallet string, ecosystemId int64) (*response.TokenBalanc
 	if err != nil {
 		return &result, err
 	}
-	err = q.GET(req, &result)
+	err = q.GETContext(ctx, req, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -175,6 +205,10 @@ func (q *query) Balance(wallet string, ecosystemId int64) (*response.TokenBalanc
 }
 
 func (q *query) GetMaxBlockID() (int64, error) {
+	return q.GetMaxBlockIDContext(context.Background())
+}
+
+func (q *query) GetMaxBlockIDContext(ctx context.Context) (int64, error) {
 	var result int64
 	message := request.RequestParams{
 		Namespace: request.NamespaceIBAX,
@@ -184,7 +218,7 @@ func (q *query) GetMaxBlockID() (int64, error) {
 	if err != nil {
 		return result, err
 	}
-	err = q.GET(req, &result)
+	err = q.GETContext(ctx, req, &result)
 	if err != nil {
 		return result, err
 	}
@@ -192,6 +226,10 @@ func (q *query) GetMaxBlockID() (int64, error) {
 }
 
 func (q *query) GetBlockInfo(id int64) (*response.BlockInfoResult, error) {
+	return q.GetBlockInfoContext(context.Background(), id)
+}
+
+func (q *query) GetBlockInfoContext(ctx context.Context, id int64) (*response.BlockInfoResult, error) {
 	var result response.BlockInfoResult
 	message := request.RequestParams{
 		Namespace: request.NamespaceIBAX,
@@ -202,7 +240,7 @@ func (q *query) GetBlockInfo(id int64) (*response.BlockInfoResult, error) {
 	if err != nil {
 		return &result, err
 	}
-	err = q.GET(req, &result)
+	err = q.GETContext(ctx, req, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -210,6 +248,10 @@ func (q *query) GetBlockInfo(id int64) (*response.BlockInfoResult, error) {
 }
 
 func (q *query) AppParams(appid int64, names string, ecosystemId int64, params ...int) (*response.AppParamsResult, error) {
+	return q.AppParamsContext(context.Background(), appid, names, ecosystemId, params...)
+}
+
+func (q *query) AppParamsContext(ctx context.Context, appid int64, names string, ecosystemId int64, params ...int) (*response.AppParamsResult, error) {
 	type appParamsResult struct {
 		App  int64                  `json:"app_id"`
 		List []response.ParamResult `json:"list"`
@@ -219,7 +261,7 @@ func (q *query) AppParams(appid int64, names string, ecosystemId int64, params .
 	var rets response.AppParamsResult
 	var offset, limit int
 	if len(params) > 2 {
-		return nil, errors.New("params invalid")
+		return nil, response.ErrInvalidParams
 	}
 	for k, v := range params {
 		if k == 0 {
@@ -238,7 +280,7 @@ func (q *query) AppParams(appid int64, names string, ecosystemId int64, params .
 	if err != nil {
 		return &rets, err
 	}
-	err = q.GET(req, &rlt)
+	err = q.GETContext(ctx, req, &rlt)
 	if err != nil {
 		return &rets, err
 	}
@@ -249,6 +291,10 @@ func (q *query) AppParams(appid int64, names string, ecosystemId int64, params .
 
 // GetRow return where table id = id AND Login ecosystem = ecosystem, rowsName optional
 func (q *query) GetRow(tableName string, id int64, columns string, whereColumn string) (*response.RowResult, error) {
+	return q.GetRowContext(context.Background(), tableName, id, columns, whereColumn)
+}
+
+func (q *query) GetRowContext(ctx context.Context, tableName string, id int64, columns string, whereColumn string) (*response.RowResult, error) {
 	var result response.RowResult
 	message := request.RequestParams{
 		Namespace: request.NamespaceIBAX,
@@ -261,7 +307,7 @@ func (q *query) GetRow(tableName string, id int64, columns string, whereColumn s
 		return &result, err
 	}
 
-	err = q.GET(req, &result)
+	err = q.GETContext(ctx, req, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -270,6 +316,10 @@ func (q *query) GetRow(tableName string, id int64, columns string, whereColumn s
 
 // GetPageRow return page name = name and ecosystem id = login ecosystem id
 func (q *query) GetPageRow(name string) (*response.PageResult, error) {
+	return q.GetPageRowContext(context.Background(), name)
+}
+
+func (q *query) GetPageRowContext(ctx context.Context, name string) (*response.PageResult, error) {
 	var result response.PageResult
 	message := request.RequestParams{
 		Namespace: request.NamespaceIBAX,
@@ -280,7 +330,7 @@ func (q *query) GetPageRow(name string) (*response.PageResult, error) {
 	if err != nil {
 		return &result, err
 	}
-	err = q.GET(req, &result)
+	err = q.GETContext(ctx, req, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -289,6 +339,10 @@ func (q *query) GetPageRow(name string) (*response.PageResult, error) {
 
 // GetMenuRow return menu name = name and ecosystem id = login ecosystem id
 func (q *query) GetMenuRow(name string) (*response.MenuResult, error) {
+	return q.GetMenuRowContext(context.Background(), name)
+}
+
+func (q *query) GetMenuRowContext(ctx context.Context, name string) (*response.MenuResult, error) {
 	var result response.MenuResult
 	message := request.RequestParams{
 		Namespace: request.NamespaceIBAX,
@@ -299,7 +353,7 @@ func (q *query) GetMenuRow(name string) (*response.MenuResult, error) {
 	if err != nil {
 		return &result, err
 	}
-	err = q.GET(req, &result)
+	err = q.GETContext(ctx, req, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -308,6 +362,10 @@ func (q *query) GetMenuRow(name string) (*response.MenuResult, error) {
 
 // GetSnippetRow return snippet name = name and ecosystem id = login ecosystem id
 func (q *query) GetSnippetRow(name string) (*response.SnippetResult, error) {
+	return q.GetSnippetRowContext(context.Background(), name)
+}
+
+func (q *query) GetSnippetRowContext(ctx context.Context, name string) (*response.SnippetResult, error) {
 	var result response.SnippetResult
 	message := request.RequestParams{
 		Namespace: request.NamespaceIBAX,
@@ -318,7 +376,7 @@ func (q *query) GetSnippetRow(name string) (*response.SnippetResult, error) {
 	if err != nil {
 		return &result, err
 	}
-	err = q.GET(req, &result)
+	err = q.GETContext(ctx, req, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -327,6 +385,10 @@ func (q *query) GetSnippetRow(name string) (*response.SnippetResult, error) {
 
 // GetAppContent Get Obtain application-related information (including page, snippet, menu)
 func (q *query) GetAppContent(appId int64) (*response.AppContentResult, error) {
+	return q.GetAppContentContext(context.Background(), appId)
+}
+
+func (q *query) GetAppContentContext(ctx context.Context, appId int64) (*response.AppContentResult, error) {
 	var result response.AppContentResult
 	message := request.RequestParams{
 		Namespace: request.NamespaceIBAX,
@@ -337,7 +399,7 @@ func (q *query) GetAppContent(appId int64) (*response.AppContentResult, error) {
 	if err != nil {
 		return &result, err
 	}
-	err = q.GET(req, &result)
+	err = q.GETContext(ctx, req, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -345,6 +407,10 @@ func (q *query) GetAppContent(appId int64) (*response.AppContentResult, error) {
 }
 
 func (q *query) BlocksTxInfo(blockId, count int64) (*map[int64][]response.BlockTxInfo, error) {
+	return q.BlocksTxInfoContext(context.Background(), blockId, count)
+}
+
+func (q *query) BlocksTxInfoContext(ctx context.Context, blockId, count int64) (*map[int64][]response.BlockTxInfo, error) {
 	var result map[int64][]response.BlockTxInfo
 	message := request.RequestParams{
 		Namespace: request.NamespaceIBAX,
@@ -355,7 +421,7 @@ func (q *query) BlocksTxInfo(blockId, count int64) (*map[int64][]response.BlockT
 	if err != nil {
 		return &result, err
 	}
-	err = q.GET(req, &result)
+	err = q.GETContext(ctx, req, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -363,6 +429,10 @@ func (q *query) BlocksTxInfo(blockId, count int64) (*map[int64][]response.BlockT
 }
 
 func (q *query) DetailedBlocks(blockId, count int64) (*map[int64]response.BlockDetailedInfo, error) {
+	return q.DetailedBlocksContext(context.Background(), blockId, count)
+}
+
+func (q *query) DetailedBlocksContext(ctx context.Context, blockId, count int64) (*map[int64]response.BlockDetailedInfo, error) {
 	var result map[int64]response.BlockDetailedInfo
 	message := request.RequestParams{
 		Namespace: request.NamespaceIBAX,
@@ -373,7 +443,7 @@ func (q *query) DetailedBlocks(blockId, count int64) (*map[int64]response.BlockD
 	if err != nil {
 		return &result, err
 	}
-	err = q.GET(req, &result)
+	err = q.GETContext(ctx, req, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -403,6 +473,10 @@ func blockIdOrBlockHashValidate(req any) error {
 }
 
 func (q *query) DetailedBlock(params any) (*response.BlockDetailedInfo, error) {
+	return q.DetailedBlockContext(context.Background(), params)
+}
+
+func (q *query) DetailedBlockContext(ctx context.Context, params any) (*response.BlockDetailedInfo, error) {
 	err := blockIdOrBlockHashValidate(params)
 	if err != nil {
 		return nil, err
@@ -418,7 +492,7 @@ func (q *query) DetailedBlock(params any) (*response.BlockDetailedInfo, error) {
 	if err != nil {
 		return &result, err
 	}
-	err = q.GET(req, &result)
+	err = q.GETContext(ctx, req, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -426,6 +500,10 @@ func (q *query) DetailedBlock(params any) (*response.BlockDetailedInfo, error) {
 }
 
 func (q *query) TransactionsCount() (int64, error) {
+	return q.TransactionsCountContext(context.Background())
+}
+
+func (q *query) TransactionsCountContext(ctx context.Context) (int64, error) {
 	var result int64
 	message := request.RequestParams{
 		Namespace: request.NamespaceIBAX,
@@ -436,7 +514,7 @@ func (q *query) TransactionsCount() (int64, error) {
 		return result, err
 	}
 
-	err = q.GET(req, &result)
+	err = q.GETContext(ctx, req, &result)
 	if err != nil {
 		return result, err
 	}
@@ -444,6 +522,10 @@ func (q *query) TransactionsCount() (int64, error) {
 }
 
 func (q *query) BlockTxCount(blockIdOrHash any) (int64, error) {
+	return q.BlockTxCountContext(context.Background(), blockIdOrHash)
+}
+
+func (q *query) BlockTxCountContext(ctx context.Context, blockIdOrHash any) (int64, error) {
 	var result int64
 	err := blockIdOrBlockHashValidate(blockIdOrHash)
 	if err != nil {
@@ -459,7 +541,7 @@ func (q *query) BlockTxCount(blockIdOrHash any) (int64, error) {
 		return result, err
 	}
 
-	err = q.GET(req, &result)
+	err = q.GETContext(ctx, req, &result)
 	if err != nil {
 		return result, err
 	}
@@ -467,6 +549,10 @@ func (q *query) BlockTxCount(blockIdOrHash any) (int64, error) {
 }
 
 func (q *query) KeysCount() (int64, error) {
+	return q.KeysCountContext(context.Background())
+}
+
+func (q *query) KeysCountContext(ctx context.Context) (int64, error) {
 	var result int64
 	message := request.RequestParams{
 		Namespace: request.NamespaceIBAX,
@@ -476,7 +562,7 @@ func (q *query) KeysCount() (int64, error) {
 	if err != nil {
 		return result, err
 	}
-	err = q.GET(req, &result)
+	err = q.GETContext(ctx, req, &result)
 	if err != nil {
 		return result, err
 	}
@@ -484,6 +570,10 @@ func (q *query) KeysCount() (int64, error) {
 }
 
 func (q *query) HonorNodesCount() (int64, error) {
+	return q.HonorNodesCountContext(context.Background())
+}
+
+func (q *query) HonorNodesCountContext(ctx context.Context) (int64, error) {
 	var result int64
 	message := request.RequestParams{
 		Namespace: request.NamespaceIBAX,
@@ -493,7 +583,7 @@ func (q *query) HonorNodesCount() (int64, error) {
 	if err != nil {
 		return result, err
 	}
-	err = q.GET(req, &result)
+	err = q.GETContext(ctx, req, &result)
 	if err != nil {
 		return result, err
 	}
@@ -501,6 +591,10 @@ func (q *query) HonorNodesCount() (int64, error) {
 }
 
 func (q *query) GetTableCount(offset, limit int) (*response.TablesResult, error) {
+	return q.GetTableCountContext(context.Background(), offset, limit)
+}
+
+func (q *query) GetTableCountContext(ctx context.Context, offset, limit int) (*response.TablesResult, error) {
 	var v response.TablesResult
 	message := request.RequestParams{
 		Namespace: request.NamespaceIBAX,
@@ -511,7 +605,7 @@ func (q *query) GetTableCount(offset, limit int) (*response.TablesResult, error)
 	if err != nil {
 		return &v, err
 	}
-	err = q.GET(req, &v)
+	err = q.GETContext(ctx, req, &v)
 	if err != nil {
 		return &v, err
 	}
@@ -519,6 +613,10 @@ func (q *query) GetTableCount(offset, limit int) (*response.TablesResult, error)
 }
 
 func (q *query) GetTable(tableName string) (*response.TableResult, error) {
+	return q.GetTableContext(context.Background(), tableName)
+}
+
+func (q *query) GetTableContext(ctx context.Context, tableName string) (*response.TableResult, error) {
 	var result response.TableResult
 	message := request.RequestParams{
 		Namespace: request.NamespaceIBAX,
@@ -529,7 +627,7 @@ func (q *query) GetTable(tableName string) (*response.TableResult, error) {
 	if err != nil {
 		return &result, err
 	}
-	err = q.GET(req, &result)
+	err = q.GETContext(ctx, req, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -542,6 +640,10 @@ type sectionsForm struct {
 }
 
 func (q *query) GetSections(language string, offset, limit int) (*response.ListResult, error) {
+	return q.GetSectionsContext(context.Background(), language, offset, limit)
+}
+
+func (q *query) GetSectionsContext(ctx context.Context, language string, offset, limit int) (*response.ListResult, error) {
 	var params sectionsForm
 	params.Lang = language
 	params.Offset = offset
@@ -557,7 +659,7 @@ func (q *query) GetSections(language string, offset, limit int) (*response.ListR
 	if err != nil {
 		return &result, err
 	}
-	err = q.GET(req, &result)
+	err = q.GETContext(ctx, req, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -565,6 +667,10 @@ func (q *query) GetSections(language string, offset, limit int) (*response.ListR
 }
 
 func (q *query) GetIBAXConfig(option string) (*string, error) {
+	return q.GetIBAXConfigContext(context.Background(), option)
+}
+
+func (q *query) GetIBAXConfigContext(ctx context.Context, option string) (*string, error) {
 	var result string
 	message := request.RequestParams{
 		Namespace: request.NamespaceIBAX,
@@ -575,7 +681,7 @@ func (q *query) GetIBAXConfig(option string) (*string, error) {
 	if err != nil {
 		return &result, err
 	}
-	err = q.GET(req, &result)
+	err = q.GETContext(ctx, req, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -583,6 +689,10 @@ func (q *query) GetIBAXConfig(option string) (*string, error) {
 }
 
 func (q *query) GetVersion() (*string, error) {
+	return q.GetVersionContext(context.Background())
+}
+
+func (q *query) GetVersionContext(ctx context.Context) (*string, error) {
 	var result string
 	message := request.RequestParams{
 		Namespace: request.NamespaceIBAX,
@@ -592,7 +702,7 @@ func (q *query) GetVersion() (*string, error) {
 	if err != nil {
 		return &result, err
 	}
-	err = q.GET(req, &result)
+	err = q.GETContext(ctx, req, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -600,6 +710,12 @@ func (q *query) GetVersion() (*string, error) {
 }
 
 func (q *query) GetList(params request.GetList) (*response.ListResult, error) {
+	return q.GetListContext(context.Background(), params)
+}
+
+func (q *query) GetListContext(ctx context.Context, params request.GetList) (_ *response.ListResult, err error) {
+	ctx, span := tracing.Of(q.Base).Start(ctx, "GetList", "table", params.Name)
+	defer tracing.End(span, &err)
 	var result response.ListResult
 	message := request.RequestParams{
 		Namespace: request.NamespaceIBAX,
@@ -611,7 +727,7 @@ func (q *query) GetList(params request.GetList) (*response.ListResult, error) {
 		return &result, err
 	}
 
-	err = q.POST(req, &result)
+	err = q.POSTContext(ctx, req, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -619,6 +735,10 @@ func (q *query) GetList(params request.GetList) (*response.ListResult, error) {
 }
 
 func (q *query) GetMemberInfo(account string, ecosystem int64) (*response.MemberInfo, error) {
+	return q.GetMemberInfoContext(context.Background(), account, ecosystem)
+}
+
+func (q *query) GetMemberInfoContext(ctx context.Context, account string, ecosystem int64) (*response.MemberInfo, error) {
 	var result response.MemberInfo
 	message := request.RequestParams{
 		Namespace: request.NamespaceIBAX,
@@ -630,7 +750,7 @@ func (q *query) GetMemberInfo(account string, ecosystem int64) (*response.Member
 		return &result, err
 	}
 
-	err = q.POST(req, &result)
+	err = q.POSTContext(ctx, req, &result)
 	if err != nil {
 		return &result, err
 	}
@@ -638,14 +758,28 @@ func (q *query) GetMemberInfo(account string, ecosystem int64) (*response.Member
 }
 
 func (q *query) GetBalance(wallet string) (*response.TokenBalanceResult, error) {
-	return nil, response.NotSupportError
+	return q.GetBalanceContext(context.Background(), wallet)
+}
+
+// GetBalanceContext gets the balance of wallet in the configured ecosystem
+func (q *query) GetBalanceContext(ctx context.Context, wallet string) (*response.TokenBalanceResult, error) {
+	return q.BalanceContext(ctx, wallet, q.GetConfig().Ecosystem)
 }
 
 func (q *query) BlocksCount() (int64, error) {
-	return 0, response.NotSupportError
+	return q.BlocksCountContext(context.Background())
+}
+
+// BlocksCountContext returns the max block id, the count of the blocks since they are numbered from 1
+func (q *query) BlocksCountContext(ctx context.Context) (int64, error) {
+	return q.GetMaxBlockIDContext(ctx)
 }
 
 func (q *query) DataVerify(tableName string, id int64, column, hash, fileName string) (result request.FileType, err error) {
+	return q.DataVerifyContext(context.Background(), tableName, id, column, hash, fileName)
+}
+
+func (q *query) DataVerifyContext(ctx context.Context, tableName string, id int64, column, hash, fileName string) (result request.FileType, err error) {
 	message := request.RequestParams{
 		Namespace: request.NamespaceIBAX,
 		Name:      "dataVerify",
@@ -658,7 +792,7 @@ func (q *query) DataVerify(tableName string, id int64, column, hash, fileName st
 	if fileName != "" {
 		result.Name = fileName
 	}
-	err = q.POST(req, &result)
+	err = q.POSTContext(ctx, req, &result)
 	if err != nil {
 		return request.FileType{}, err
 	}
@@ -666,6 +800,10 @@ func (q *query) DataVerify(tableName string, id int64, column, hash, fileName st
 }
 
 func (q *query) BinaryVerify(id int64, hash, fileName string) (result request.FileType, err error) {
+	return q.BinaryVerifyContext(context.Background(), id, hash, fileName)
+}
+
+func (q *query) BinaryVerifyContext(ctx context.Context, id int64, hash, fileName string) (result request.FileType, err error) {
 	message := request.RequestParams{
 		Namespace: request.NamespaceIBAX,
 		Name:      "binaryVerify",
@@ -680,7 +818,7 @@ func (q *query) BinaryVerify(id int64, hash, fileName string) (result request.Fi
 	if fileName != "" {
 		result.Name = fileName
 	}
-	err = q.POST(req, &result)
+	err = q.POSTContext(ctx, req, &result)
 	if err != nil {
 		return result, err
 	}
@@ -688,30 +826,101 @@ func (q *query) BinaryVerify(id int64, hash, fileName string) (result request.Fi
 }
 
 func (q *query) EcosystemName(ecosystem int64) (*response.EcosystemNameResult, error) {
-	return nil, response.NotSupportError
+	return q.EcosystemNameContext(context.Background(), ecosystem)
+}
+
+func (q *query) EcosystemNameContext(ctx context.Context, ecosystem int64) (*response.EcosystemNameResult, error) {
+	var result response.EcosystemNameResult
+	info, err := q.EcosystemInfoContext(ctx, ecosystem)
+	if err != nil {
+		return &result, err
+	}
+	result.EcosystemName = info.Name
+	return &result, nil
 }
 
 func (q *query) AppParam(appid int64, name string, ecosystem int64) (*response.ParamResult, error) {
-	return nil, response.NotSupportError
+	return q.AppParamContext(context.Background(), appid, name, ecosystem)
+}
+
+func (q *query) AppParamContext(ctx context.Context, appid int64, name string, ecosystem int64) (*response.ParamResult, error) {
+	params, err := q.AppParamsContext(ctx, appid, name, ecosystem)
+	if err != nil {
+		return &response.ParamResult{}, err
+	}
+	return findParam(params.List, name)
 }
 
 func (q *query) EcosystemParam(ecosystem int64, name string) (*response.ParamResult, error) {
-	return nil, response.NotSupportError
+	return q.EcosystemParamContext(context.Background(), ecosystem, name)
+}
+
+func (q *query) EcosystemParamContext(ctx context.Context, ecosystem int64, name string) (*response.ParamResult, error) {
+	params, err := q.EcosystemParamsContext(ctx, ecosystem, name)
+	if err != nil {
+		return &response.ParamResult{}, err
+	}
+	return findParam(params.List, name)
+}
+
+// findParam picks the parameter name from list, the node lists the parameters of the names it has found
+func findParam(list []response.ParamResult, name string) (*response.ParamResult, error) {
+	for _, v := range list {
+		if v.Name == name {
+			return &v, nil
+		}
+	}
+	return &response.ParamResult{}, fmt.Errorf("parameter %s %w", name, response.ErrNotFound)
 }
 
 func (q *query) GetRowExtend(tableName, columns string, value string, rowsName string) (*response.RowResult, error) {
-	return nil, response.NotSupportError
+	return q.GetRowExtendContext(context.Background(), tableName, columns, value, rowsName)
+}
+
+// GetRowExtendContext gets the first row where the column columns equals value by getList
+func (q *query) GetRowExtendContext(ctx context.Context, tableName, columns string, value string, rowsName string) (*response.RowResult, error) {
+	var result response.RowResult
+	params := request.GetList{Where: map[string]any{columns: value}}
+	params.Name = tableName
+	params.Limit = 1
+	params.Columns = rowsName
+	list, err := q.GetListContext(ctx, params)
+	if err != nil {
+		return &result, err
+	}
+	if len(list.List) == 0 {
+		return &result, fmt.Errorf("row %s=%s of %s %w", columns, value, tableName, response.ErrNotFound)
+	}
+	result.Value = list.List[0]
+	return &result, nil
 }
 
 func (q *query) GetListWhere(params request.GetList) (*response.ListResult, error) {
-	return nil, response.NotSupportError
+	return q.GetListWhereContext(context.Background(), params)
+}
+
+// GetListWhereContext gets the list by getList, which takes the where and order of listWhere
+func (q *query) GetListWhereContext(ctx context.Context, params request.GetList) (*response.ListResult, error) {
+	if params.Offset < 0 || params.Limit <= 0 {
+		return &response.ListResult{}, response.ErrInvalidParams
+	}
+	return q.GetListContext(ctx, params)
 }
 
 func (q *query) GetNodeListWhere(params request.GetList) (*response.ListResult, error) {
-	return nil, response.NotSupportError
+	return q.GetNodeListWhereContext(context.Background(), params)
+}
+
+// GetNodeListWhereContext is GetListWhereContext, the node serves nodelistWhere and listWhere by the same handler
+func (q *query) GetNodeListWhereContext(ctx context.Context, params request.GetList) (*response.ListResult, error) {
+	return q.GetListWhereContext(ctx, params)
 }
 
 func (q *query) GetAvatar(account string, ecosystem int64, fileName string) (result request.FileType, err error) {
+	return q.GetAvatarContext(context.Background(), account, ecosystem, fileName)
+}
+
+func (q *query) GetAvatarContext(ctx context.Context, account string, ecosystem int64, fileName string) (result request.FileType, err error) {
 	message := request.RequestParams{
 		Namespace: request.NamespaceIBAX,
 		Name:      "getAvatar",
@@ -729,7 +938,7 @@ func (q *query) GetAvatar(account string, ecosystem int64, fileName string) (res
 	} else {
 		return result, errors.New("filename can't not be empty")
 	}
-	err = q.POST(req, v)
+	err = q.POSTContext(ctx, req, v)
 	if err != nil {
 		return result, err
 	}
@@ -737,3 +946,40 @@ func (q *query) GetAvatar(account string, ecosystem int64, fileName string) (res
 	result = *v
 	return result, nil
 }
+
+func (q *query) DataVerifyTo(stream *response.FileStream, tableName string, id int64, column, hash string) error {
+	return q.DataVerifyToContext(context.Background(), stream, tableName, id, column, hash)
+}
+
+func (q *query) DataVerifyToContext(ctx context.Context, stream *response.FileStream, tableName string, id int64, column, hash string) error {
+	return q.sendFile(ctx, stream, "dataVerify", tableName, column, id, hash)
+}
+
+func (q *query) BinaryVerifyTo(stream *response.FileStream, id int64, hash string) error {
+	return q.BinaryVerifyToContext(context.Background(), stream, id, hash)
+}
+
+func (q *query) BinaryVerifyToContext(ctx context.Context, stream *response.FileStream, id int64, hash string) error {
+	return q.sendFile(ctx, stream, "binaryVerify", id, hash)
+}
+
+func (q *query) GetAvatarTo(stream *response.FileStream, account string, ecosystem int64) error {
+	return q.GetAvatarToContext(context.Background(), stream, account, ecosystem)
+}
+
+func (q *query) GetAvatarToContext(ctx context.Context, stream *response.FileStream, account string, ecosystem int64) error {
+	return q.sendFile(ctx, stream, "getAvatar", account, ecosystem)
+}
+
+// sendFile sends the method with params, the file response is streamed into stream
+func (q *query) sendFile(ctx context.Context, stream *response.FileStream, method string, params ...any) error {
+	req, err := q.NewMessage(request.RequestParams{
+		Namespace: request.NamespaceIBAX,
+		Name:      method,
+		Params:    params,
+	})
+	if err != nil {
+		return err
+	}
+	return q.POSTContext(ctx, req, stream)
+}
@@ -1,12 +1,16 @@
 package tx
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"github.com/IBAX-io/go-ibax-sdk/packages/metrics"
 	"github.com/IBAX-io/go-ibax-sdk/packages/modus"
 	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/converter"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/utils"
 	"github.com/IBAX-io/go-ibax-sdk/packages/request"
 	"github.com/IBAX-io/go-ibax-sdk/packages/response"
+	"github.com/IBAX-io/go-ibax-sdk/packages/tracing"
 	"strconv"
 	"strings"
 	"time"
@@ -39,14 +43,24 @@ func New(b modus.Base) modus.Transaction {
 // hash: transaction hash
 // interval: After the transaction is sent, the time interval for each query of the transaction status
 func (t *tx) TxStatus(hash string, frequency int, interval time.Duration) (response.TxStatusResult, error) {
-	return t.waitTx(hash, frequency, interval)
+	return t.TxStatusContext(context.Background(), hash, frequency, interval)
 }
 
-func (t *tx) waitTx(hash string, frequency int, interval time.Duration) (rets response.TxStatusResult, err error) {
+func (t *tx) TxStatusContext(ctx context.Context, hash string, frequency int, interval time.Duration) (response.TxStatusResult, error) {
+	return t.waitTx(ctx, hash, frequency, interval)
+}
+
+func (t *tx) waitTx(ctx context.Context, hash string, frequency int, interval time.Duration) (rets response.TxStatusResult, err error) {
+	start := time.Now()
+	tracer := tracing.Of(t.baseClient)
+	ctx, span := tracer.Start(ctx, "TxStatus", "hash", hash)
+	defer tracing.End(span, &err)
 	if interval.Milliseconds() < waitTxMinInterval.Milliseconds() {
 		interval = waitTxMinInterval
 	}
-	time.Sleep(interval)
+	if err = utils.Sleep(ctx, interval); err != nil {
+		return
+	}
 	message := request.RequestParams{
 		Namespace: request.NamespaceIBAX,
 		Name:      "txStatus",
@@ -59,12 +73,16 @@ func (t *tx) waitTx(hash string, frequency int, interval time.Duration) (rets re
 	rets.Hash = hash
 	for i := 1; i < frequency; i++ {
 		var multiRet map[string]*txStatus
-		err = t.baseClient.POST(req, &multiRet)
+		pollCtx, poll := tracer.Start(ctx, "TxStatusPoll", "attempt", i)
+		err = t.baseClient.POSTContext(pollCtx, req, &multiRet)
+		tracing.End(poll, &err)
 		if err != nil {
 			return
 		}
 		if len(multiRet) == 0 {
-			time.Sleep(interval)
+			if err = utils.Sleep(ctx, interval); err != nil {
+				return
+			}
 			continue
 		}
 
@@ -73,6 +91,8 @@ func (t *tx) waitTx(hash string, frequency int, interval time.Duration) (rets re
 		if len(ret.BlockID) > 0 {
 			rets.BlockId = converter.StrToInt64(ret.BlockID)
 			rets.Penalty = ret.Penalty
+			metrics.Of(t.baseClient).ObserveTxConfirmed(time.Since(start), ret.Penalty == 1)
+			span.SetAttributes("block_id", rets.BlockId, "penalty", ret.Penalty)
 			if ret.Penalty == 1 {
 				errText, err = json.Marshal(ret.Message)
 				if err != nil {
@@ -97,7 +117,9 @@ func (t *tx) waitTx(hash string, frequency int, interval time.Duration) (rets re
 			rets.Err = string(errText)
 			return
 		}
-		time.Sleep(interval)
+		if err = utils.Sleep(ctx, interval); err != nil {
+			return
+		}
 	}
 
 	return rets, nil
@@ -107,10 +129,17 @@ func (t *tx) waitTx(hash string, frequency int, interval time.Duration) (rets re
 // hashList: multiple transaction hash
 // interval: After the transaction is sent, the time interval for each query of the transaction status
 func (t *tx) TxsStatus(hashList []string, interval time.Duration) (map[string]response.TxStatusResult, error) {
+	return t.TxsStatusContext(context.Background(), hashList, interval)
+}
+
+func (t *tx) TxsStatusContext(ctx context.Context, hashList []string, interval time.Duration) (map[string]response.TxStatusResult, error) {
+	start := time.Now()
 	if interval.Milliseconds() < waitTxMinInterval.Milliseconds() {
 		interval = waitTxMinInterval
 	}
-	time.Sleep(interval)
+	if err := utils.Sleep(ctx, interval); err != nil {
+		return nil, err
+	}
 	hashes := strings.Join(hashList, ",")
 	message := request.RequestParams{
 		Namespace: request.NamespaceIBAX,
@@ -133,7 +162,7 @@ func (t *tx) TxsStatus(hashList []string, interval time.Duration) (map[string]re
 again:
 	//var multiRet multiTxStatusResult
 	var multiRet map[string]*txStatus
-	err = t.baseClient.POST(req, &multiRet)
+	err = t.baseClient.POSTContext(ctx, req, &multiRet)
 	if err != nil {
 		return nil, err
 	}
@@ -144,6 +173,7 @@ again:
 		if len(v.BlockID) > 0 {
 			result.BlockId = converter.StrToInt64(v.BlockID)
 			result.Penalty = v.Penalty
+			metrics.Of(t.baseClient).ObserveTxConfirmed(time.Since(start), v.Penalty == 1)
 			if v.Penalty == 1 {
 				errtext, err = json.Marshal(v.Message)
 				if err != nil {
@@ -176,7 +206,9 @@ again:
 	}
 	if len(rets) == 0 && againNumber < 10 {
 		againNumber++
-		time.Sleep(interval)
+		if err = utils.Sleep(ctx, interval); err != nil {
+			return nil, err
+		}
 		goto again
 	}
 
@@ -184,6 +216,10 @@ again:
 }
 
 func (t *tx) SendTx(arrData map[string][]byte) (*map[string]string, error) {
+	return t.SendTxContext(context.Background(), arrData)
+}
+
+func (t *tx) SendTxContext(ctx context.Context, arrData map[string][]byte) (*map[string]string, error) {
 	ret := &response.SendTxResult{}
 	hashMap := map[string]string{}
 	message := request.RequestParams{
@@ -195,12 +231,20 @@ func (t *tx) SendTx(arrData map[string][]byte) (*map[string]string, error) {
 	if err != nil {
 		return &hashMap, err
 	}
-	err = t.baseClient.POST(req, &ret)
+	err = t.baseClient.POSTContext(ctx, req, &ret)
 	if err != nil {
 		return &hashMap, err
 	}
 
+	metrics.Of(t.baseClient).AddTxSent(len(arrData))
 	hashMap = ret.Hashes
+	if len(hashMap) == 0 {
+		//the node had received the transactions before they were sent again
+		hashMap = make(map[string]string, len(arrData))
+		for key := range arrData {
+			hashMap[key] = key
+		}
+	}
 	return &hashMap, nil
 }
 
@@ -211,6 +255,10 @@ type txInfoResult struct {
 }
 
 func (t *tx) GetTxInfo(hash string, getContractInfo bool) (*response.TxInfoResult, error) {
+	return t.GetTxInfoContext(context.Background(), hash, getContractInfo)
+}
+
+func (t *tx) GetTxInfoContext(ctx context.Context, hash string, getContractInfo bool) (*response.TxInfoResult, error) {
 	var rlt response.TxInfoResult
 	var result txInfoResult
 	message := request.RequestParams{
@@ -222,7 +270,7 @@ func (t *tx) GetTxInfo(hash string, getContractInfo bool) (*response.TxInfoResul
 	if err != nil {
 		return &rlt, err
 	}
-	err = t.baseClient.POST(req, &result)
+	err = t.baseClient.POSTContext(ctx, req, &result)
 	if err != nil {
 		return &rlt, err
 	}
@@ -234,6 +282,10 @@ func (t *tx) GetTxInfo(hash string, getContractInfo bool) (*response.TxInfoResul
 }
 
 func (t *tx) GetTxInfoMulti(hashList []string, getContractInfo bool) (*response.MultiTxInfoResult, error) {
+	return t.GetTxInfoMultiContext(context.Background(), hashList, getContractInfo)
+}
+
+func (t *tx) GetTxInfoMultiContext(ctx context.Context, hashList []string, getContractInfo bool) (*response.MultiTxInfoResult, error) {
 	type multiTxInfoResult struct {
 		Results map[string]*txInfoResult `json:"results"`
 	}
@@ -249,7 +301,7 @@ func (t *tx) GetTxInfoMulti(hashList []string, getContractInfo bool) (*response.
 		return &result, err
 	}
 
-	err = t.baseClient.GET(req, &rlt)
+	err = t.baseClient.GETContext(ctx, req, &rlt)
 	if err != nil {
 		return &result, err
 	}
@@ -1,15 +1,18 @@
 package utxo
 
 import (
+	"context"
 	"encoding/hex"
-	"errors"
 	"fmt"
+	"github.com/IBAX-io/go-ibax-sdk/packages/logger"
+	"github.com/IBAX-io/go-ibax-sdk/packages/metrics"
 	"github.com/IBAX-io/go-ibax-sdk/packages/modus"
 	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/converter"
 	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/transaction"
 	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/types"
 	"github.com/IBAX-io/go-ibax-sdk/packages/request"
 	"github.com/IBAX-io/go-ibax-sdk/packages/response"
+	"github.com/IBAX-io/go-ibax-sdk/packages/tracing"
 	"github.com/shopspring/decimal"
 	"time"
 )
@@ -48,7 +51,7 @@ func (ux *utxo) NewUtxoSmartTransaction(txType request.UtxoType, form modus.Gett
 	}
 	amount := form.Get("amount")
 	if len(amount) == 0 {
-		return &smartTx, errors.New("amount params invalid")
+		return &smartTx, fmt.Errorf("%w: amount", response.ErrInvalidParams)
 	}
 	err := ux.AmountValidator(amount)
 	if err != nil {
@@ -59,7 +62,7 @@ func (ux *utxo) NewUtxoSmartTransaction(txType request.UtxoType, form modus.Gett
 		recipient := form.Get("recipient")
 		comment := form.Get("comment")
 		if len(recipient) == 0 {
-			return &smartTx, errors.New("recipient params invalid")
+			return &smartTx, fmt.Errorf("%w: recipient", response.ErrInvalidParams)
 		}
 		toId := converter.StringToAddress(recipient)
 		if toId == 0 && recipient != request.BlackHoleAddr {
@@ -88,18 +91,19 @@ func (ux *utxo) NewUtxoSmartTransaction(txType request.UtxoType, form modus.Gett
 }
 
 func (ux *utxo) NewUtxoTransaction(smartTransaction types.SmartTransaction) (data, hash []byte, err error) {
-	var privateKey []byte
-	cnf := ux.GetConfig()
-	if privateKey, err = hex.DecodeString(cnf.PrivateKey); err != nil {
-		return
-	}
-
-	data, hash, err = transaction.NewTransactionInProc(smartTransaction, privateKey)
+	data, hash, err = transaction.NewSignedTransactionWithLogger(context.Background(), ux.Crypto(), logger.Of(ux.Base), smartTransaction, ux.Signer())
 
 	return
 }
 
 func (ux *utxo) AutoCallUtxo(txType request.UtxoType, form modus.Getter, expedite string) (*response.TxStatusResult, error) {
+	return ux.AutoCallUtxoContext(context.Background(), txType, form, expedite)
+}
+
+func (ux *utxo) AutoCallUtxoContext(ctx context.Context, txType request.UtxoType, form modus.Getter, expedite string) (result *response.TxStatusResult, err error) {
+	tracer := tracing.Of(ux.Base)
+	ctx, span := tracer.Start(ctx, "AutoCallUtxo", "type", txType)
+	defer tracing.End(span, &err)
 	var (
 		rets = response.TxStatusResult{}
 	)
@@ -108,18 +112,15 @@ func (ux *utxo) AutoCallUtxo(txType request.UtxoType, form modus.Getter, expedit
 		return &rets, err
 	}
 
-	cnf := ux.GetConfig()
-	var privateKey []byte
-	if privateKey, err = hex.DecodeString(cnf.PrivateKey); err != nil {
-		return &rets, err
-	}
-
 	arrData := make(map[string][]byte)
-	data, txhash, err := transaction.NewTransactionInProc(*smartTx, privateKey)
+	signCtx, sign := tracer.Start(ctx, "SignTx")
+	data, txhash, err := transaction.NewSignedTransactionWithLogger(signCtx, ux.Crypto(), logger.Of(ux.Base), *smartTx, ux.Signer())
+	tracing.End(sign, &err)
 	if err != nil {
 		return &rets, err
 	}
 	arrData[fmt.Sprintf("%x", txhash)] = data
+	span.SetAttributes("hash", hex.EncodeToString(txhash))
 
 	ret := &response.SendTxResult{}
 	message := request.RequestParams{
@@ -131,16 +132,19 @@ func (ux *utxo) AutoCallUtxo(txType request.UtxoType, form modus.Getter, expedit
 	if err != nil {
 		return &rets, err
 	}
-	err = ux.POST(req, &ret)
+	sendCtx, send := tracer.Start(ctx, "SendTx")
+	err = ux.POSTContext(sendCtx, req, &ret)
+	tracing.End(send, &err)
 	if err != nil {
 		return &rets, err
 	}
+	metrics.Of(ux.Base).AddTxSent(len(arrData))
 
 	if form != nil && len(form.Get("nowait")) > 0 {
 		return &rets, nil
 	}
 
-	rets, err = ux.TxStatus(hex.EncodeToString(txhash), 10, time.Second*1)
+	rets, err = ux.TxStatusContext(ctx, hex.EncodeToString(txhash), 10, time.Second*1)
 	if err != nil {
 		return &rets, err
 	}
@@ -0,0 +1,163 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+)
+
+// maxBodySize limits the request and response bodies of the remote signer
+const maxBodySize = 1 << 16
+
+// Remote is the Signer served by Handler at URL, usually by another process holding the key.
+// The public key is requested by GET URL/public_key once and the signatures by POST URL/sign
+type Remote struct {
+	URL    string       // base URL of the signer
+	Header http.Header  // sent with every request, such as the Authorization of the signer
+	Client *http.Client // http.DefaultClient if nil
+
+	lock      sync.Mutex
+	publicKey []byte
+}
+
+type publicKeyResult struct {
+	PublicKey string `json:"public_key"`
+}
+
+type signForm struct {
+	Hash string `json:"hash"`
+}
+
+type signResult struct {
+	Signature string `json:"signature"`
+}
+
+type errorResult struct {
+	Error string `json:"error"`
+}
+
+func (r *Remote) PublicKey(ctx context.Context) ([]byte, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.publicKey != nil {
+		return r.publicKey, nil
+	}
+	var ret publicKeyResult
+	if err := r.call(ctx, http.MethodGet, "public_key", nil, &ret); err != nil {
+		return nil, err
+	}
+	pub, err := hex.DecodeString(ret.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(pub) == 0 {
+		return nil, fmt.Errorf("remote signer returned no public key")
+	}
+	r.publicKey = pub
+	return pub, nil
+}
+
+func (r *Remote) Sign(ctx context.Context, hash []byte) ([]byte, error) {
+	var ret signResult
+	if err := r.call(ctx, http.MethodPost, "sign", signForm{Hash: hex.EncodeToString(hash)}, &ret); err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(ret.Signature)
+}
+
+func (r *Remote) call(ctx context.Context, method, name string, form, result any) error {
+	var body io.Reader
+	if form != nil {
+		data, err := json.Marshal(form)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(r.URL, "/")+"/"+name, body)
+	if err != nil {
+		return err
+	}
+	for k, v := range r.Header {
+		req.Header[k] = v
+	}
+	if form != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	client := r.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var ret errorResult
+		if json.Unmarshal(data, &ret) != nil || ret.Error == "" {
+			ret.Error = strings.TrimSpace(string(data))
+		}
+		return fmt.Errorf("remote signer %s:%s", resp.Status, ret.Error)
+	}
+	return json.Unmarshal(data, result)
+}
+
+// Handler serves s to the Remote signers, at the paths ending with /public_key and /sign.
+// It should be served to the trusted clients only, such as on a local socket or behind an authenticating proxy
+func Handler(s Signer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var (
+			result any
+			err    error
+			status = http.StatusBadRequest
+		)
+		switch name := path.Base(r.URL.Path); {
+		case name == "public_key" && r.Method == http.MethodGet:
+			var pub []byte
+			if pub, err = s.PublicKey(r.Context()); err == nil {
+				result = publicKeyResult{PublicKey: hex.EncodeToString(pub)}
+			}
+			status = http.StatusInternalServerError
+		case name == "sign" && r.Method == http.MethodPost:
+			var (
+				form signForm
+				hash []byte
+			)
+			if err = json.NewDecoder(io.LimitReader(r.Body, maxBodySize)).Decode(&form); err != nil {
+				break
+			}
+			if hash, err = hex.DecodeString(form.Hash); err == nil && len(hash) == 0 {
+				err = fmt.Errorf("hash is empty")
+			}
+			if err != nil {
+				break
+			}
+			var sign []byte
+			if sign, err = s.Sign(r.Context(), hash); err == nil {
+				result = signResult{Signature: hex.EncodeToString(sign)}
+			}
+			status = http.StatusInternalServerError
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err != nil {
+			w.WriteHeader(status)
+			result = errorResult{Error: err.Error()}
+		}
+		json.NewEncoder(w).Encode(result)
+	})
+}
@@ -0,0 +1,72 @@
+package signer
+
+import (
+	"context"
+	"github.com/IBAX-io/go-ibax-sdk/packages/keysource"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/accounts"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/accounts/hdwallet"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/cryptoer"
+)
+
+// Signer signs the logins and the transactions, the private key may be kept by another process
+type Signer interface {
+	// PublicKey returns the public key of the signer, as returned by cryptoer.Provider.PrivateToPublic
+	PublicKey(ctx context.Context) ([]byte, error)
+	// Sign signs hash as it is, hash is the hash of the signed data by the hasher of the chain
+	Sign(ctx context.Context, hash []byte) ([]byte, error)
+}
+
+// keySigner signs with the private key of a key source by the algorithms of a provider
+type keySigner struct {
+	crypto cryptoer.Provider
+	source keysource.Source
+}
+
+// Key returns the Signer of privateKey kept in memory, with the algorithms of p
+func Key(p cryptoer.Provider, privateKey []byte) Signer {
+	return FromSource(p, keysource.Func(func(ctx context.Context) ([]byte, error) {
+		return privateKey, nil
+	}))
+}
+
+// FromSource returns the Signer of the private key of src, the key is read again at every signing
+func FromSource(p cryptoer.Provider, src keysource.Source) Signer {
+	return &keySigner{crypto: cryptoer.OrGlobal(p), source: src}
+}
+
+// HDWallet returns the Signer of account of the hierarchical deterministic wallet w, with the algorithms of p
+func HDWallet(p cryptoer.Provider, w *hdwallet.Wallet, account accounts.Account) Signer {
+	return FromSource(p, keysource.Func(func(ctx context.Context) ([]byte, error) {
+		return w.PrivateKey(account)
+	}))
+}
+
+// WithPublicKey returns s with its public key known, such as the one derived when the account of a client was set up
+func WithPublicKey(s Signer, publicKey []byte) Signer {
+	return &knownKey{Signer: s, publicKey: publicKey}
+}
+
+type knownKey struct {
+	Signer
+	publicKey []byte
+}
+
+func (k *knownKey) PublicKey(ctx context.Context) ([]byte, error) {
+	return k.publicKey, nil
+}
+
+func (s *keySigner) PublicKey(ctx context.Context) ([]byte, error) {
+	key, err := s.source.PrivateKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.crypto.PrivateToPublic(key)
+}
+
+func (s *keySigner) Sign(ctx context.Context, hash []byte) ([]byte, error) {
+	key, err := s.source.PrivateKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.crypto.SignHash(key, hash)
+}
@@ -0,0 +1,101 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/accounts/hdwallet"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/cryptoer"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func checkSigner(t *testing.T, p cryptoer.Provider, s Signer, wantPub []byte) {
+	t.Helper()
+	ctx := context.Background()
+	pub, err := s.PublicKey(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(pub, wantPub) {
+		t.Fatalf("want public key %x, got %x", wantPub, pub)
+	}
+	data := []byte("LOGIN1uid")
+	sign, err := s.Sign(ctx, p.Hash(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := p.Verify(pub, data, sign); !ok || err != nil {
+		t.Errorf("expected the signature of the hash to verify, %v", err)
+	}
+}
+
+func TestSigners(t *testing.T) {
+	p, err := cryptoer.New("ECC_Secp256k1", "KECCAK256")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, pub, err := p.GenKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkSigner(t, p, Key(p, key), pub)
+
+	w, err := hdwallet.NewWalletFromMnemonic("tag volcano eight thank tide danger coast health above argue embrace heavy", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := w.Derive(hdwallet.MustParseDerivationPath("m/44'/60'/0'/0/0"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	accountKey, err := w.PrivateKey(account)
+	if err != nil {
+		t.Fatal(err)
+	}
+	accountPub, err := p.PrivateToPublic(accountKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkSigner(t, p, HDWallet(p, w, account), accountPub)
+}
+
+func TestRemote(t *testing.T) {
+	p, err := cryptoer.New("ECC_Secp256k1", "KECCAK256")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, pub, err := p.GenKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var publicKeys int
+	handler := Handler(Key(p, key))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, `{"error":"unauthorized"}`, http.StatusUnauthorized)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/public_key") {
+			publicKeys++
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	remote := &Remote{URL: srv.URL + "/signer/", Header: http.Header{"Authorization": {"Bearer secret"}}}
+	checkSigner(t, p, remote, pub)
+	if _, err = remote.PublicKey(context.Background()); err != nil || publicKeys != 1 {
+		t.Errorf("expected the public key to be requested once, got %d, %v", publicKeys, err)
+	}
+
+	_, err = (&Remote{URL: srv.URL}).Sign(context.Background(), p.Hash([]byte("data")))
+	if err == nil || !strings.Contains(err.Error(), "unauthorized") {
+		t.Errorf("expected the error of the signer, got %v", err)
+	}
+	_, err = (&Remote{URL: srv.URL, Header: remote.Header}).Sign(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "hash is empty") {
+		t.Errorf("expected an empty hash to fail, got %v", err)
+	}
+}
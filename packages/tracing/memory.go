@@ -0,0 +1,104 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// SpanData is a span ended by a Memory tracer
+type SpanData struct {
+	Name        string
+	SpanContext SpanContext
+	ParentID    [8]byte // span id of the parent, zero for a root span
+	Attributes  map[string]any
+	Err         error // the last error recorded
+	Start       time.Time
+	End         time.Time
+}
+
+// Memory is a Tracer keeping the ended spans in memory, used by the tests
+type Memory struct {
+	lock  sync.Mutex
+	spans []SpanData
+}
+
+// NewMemory creates a Memory tracer
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+func (m *Memory) Start(ctx context.Context, name string, keyvals ...any) (context.Context, Span) {
+	span := &memorySpan{tracer: m, data: SpanData{Name: name, Attributes: make(map[string]any), Start: time.Now()}}
+	span.data.SpanContext.Sampled = true
+	if parent := SpanFromContext(ctx); parent != nil && parent.SpanContext().IsValid() {
+		span.data.SpanContext.TraceID = parent.SpanContext().TraceID
+		span.data.ParentID = parent.SpanContext().SpanID
+	} else {
+		rand.Read(span.data.SpanContext.TraceID[:])
+	}
+	rand.Read(span.data.SpanContext.SpanID[:])
+	span.SetAttributes(keyvals...)
+	return ContextWithSpan(ctx, span), span
+}
+
+// Spans returns the ended spans in the order they end
+func (m *Memory) Spans() []SpanData {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return append([]SpanData(nil), m.spans...)
+}
+
+// Reset drops the ended spans
+func (m *Memory) Reset() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.spans = nil
+}
+
+type memorySpan struct {
+	tracer *Memory
+	lock   sync.Mutex
+	data   SpanData
+	ended  bool
+}
+
+func (s *memorySpan) SetAttributes(keyvals ...any) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for k := 0; k+1 < len(keyvals); k += 2 {
+		s.data.Attributes[fmt.Sprint(keyvals[k])] = keyvals[k+1]
+	}
+}
+
+func (s *memorySpan) RecordError(err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.data.Err = err
+}
+
+func (s *memorySpan) End() {
+	s.lock.Lock()
+	if s.ended {
+		s.lock.Unlock()
+		return
+	}
+	s.ended = true
+	s.data.End = time.Now()
+	data := s.data
+	data.Attributes = make(map[string]any, len(s.data.Attributes))
+	for k, v := range s.data.Attributes {
+		data.Attributes[k] = v
+	}
+	s.lock.Unlock()
+
+	s.tracer.lock.Lock()
+	defer s.tracer.lock.Unlock()
+	s.tracer.spans = append(s.tracer.spans, data)
+}
+
+func (s *memorySpan) SpanContext() SpanContext {
+	return s.data.SpanContext
+}
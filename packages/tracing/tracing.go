@@ -0,0 +1,136 @@
+package tracing
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// TraceParentHeader is the W3C trace context header sent to the node
+const TraceParentHeader = "traceparent"
+
+// Tracer starts the spans of the SDK calls, set by transport.WithTracer. An OpenTelemetry tracer can be adapted,
+// Start must return the ctx of ContextWithSpan so the trace context of the span is sent to the node
+type Tracer interface {
+	// Start starts a span named name, a child of the span of ctx if any, keyvals are alternating attribute keys and values
+	Start(ctx context.Context, name string, keyvals ...any) (context.Context, Span)
+}
+
+// Span is an operation of a trace
+type Span interface {
+	SetAttributes(keyvals ...any)
+	RecordError(err error)
+	End()
+	SpanContext() SpanContext
+}
+
+// SpanContext identifies a span across the process boundary
+type SpanContext struct {
+	TraceID [16]byte
+	SpanID  [8]byte
+	Sampled bool
+}
+
+// IsValid reports whether the trace id and span id are set
+func (s SpanContext) IsValid() bool {
+	return s.TraceID != [16]byte{} && s.SpanID != [8]byte{}
+}
+
+// TraceParent formats s as the value of the traceparent header
+func (s SpanContext) TraceParent() string {
+	var flags byte
+	if s.Sampled {
+		flags = 1
+	}
+	return fmt.Sprintf("00-%x-%x-%02x", s.TraceID, s.SpanID, flags)
+}
+
+// ParseTraceParent parses the value of a traceparent header
+func ParseTraceParent(v string) (SpanContext, error) {
+	var s SpanContext
+	parts := strings.Split(strings.TrimSpace(v), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return s, fmt.Errorf("traceparent is invalid:%s", v)
+	}
+	var flags [1]byte
+	if _, err := hex.Decode(s.TraceID[:], []byte(parts[1])); err != nil {
+		return s, fmt.Errorf("traceparent is invalid:%s", v)
+	}
+	if _, err := hex.Decode(s.SpanID[:], []byte(parts[2])); err != nil {
+		return s, fmt.Errorf("traceparent is invalid:%s", v)
+	}
+	if _, err := hex.Decode(flags[:], []byte(parts[3])); err != nil {
+		return s, fmt.Errorf("traceparent is invalid:%s", v)
+	}
+	s.Sampled = flags[0]&1 == 1
+	if !s.IsValid() {
+		return s, fmt.Errorf("traceparent is invalid:%s", v)
+	}
+	return s, nil
+}
+
+type spanKey struct{}
+
+// ContextWithSpan returns a copy of ctx carrying span
+func ContextWithSpan(ctx context.Context, span Span) context.Context {
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// SpanFromContext returns the span of ctx, nil if ctx has none
+func SpanFromContext(ctx context.Context) Span {
+	span, _ := ctx.Value(spanKey{}).(Span)
+	return span
+}
+
+// Inject sets the traceparent header of the span of ctx to h, h is unchanged if ctx has no valid span
+func Inject(ctx context.Context, h http.Header) {
+	span := SpanFromContext(ctx)
+	if span == nil {
+		return
+	}
+	if s := span.SpanContext(); s.IsValid() {
+		h.Set(TraceParentHeader, s.TraceParent())
+	}
+}
+
+// End records *errp in span if it is not nil and ends span, it is deferred with the named error result
+func End(span Span, errp *error) {
+	if errp != nil && *errp != nil {
+		span.RecordError(*errp)
+	}
+	span.End()
+}
+
+type nopSpan struct{}
+
+func (nopSpan) SetAttributes(...any)     {}
+func (nopSpan) RecordError(error)        {}
+func (nopSpan) End()                     {}
+func (nopSpan) SpanContext() SpanContext { return SpanContext{} }
+
+type nop struct{}
+
+func (nop) Start(ctx context.Context, _ string, _ ...any) (context.Context, Span) {
+	return ctx, nopSpan{}
+}
+
+// Nop starts the spans recording nothing, it is the default tracer
+var Nop Tracer = nop{}
+
+// OrNop returns t, or Nop if t is nil
+func OrNop(t Tracer) Tracer {
+	if t == nil {
+		return Nop
+	}
+	return t
+}
+
+// Of returns the tracer of a client or base, Nop if v has none
+func Of(v any) Tracer {
+	if t, ok := v.(interface{ Tracer() Tracer }); ok {
+		return OrNop(t.Tracer())
+	}
+	return Nop
+}
@@ -0,0 +1,60 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestTraceParent(t *testing.T) {
+	const v = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	s, err := ParseTraceParent(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.Sampled || s.TraceParent() != v {
+		t.Errorf("want %s, got %s", v, s.TraceParent())
+	}
+	for _, invalid := range []string{"", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01", "00-4bf92f3577b34da6a3ce929d0e0e473x-00f067aa0ba902b7-01"} {
+		if _, err = ParseTraceParent(invalid); err == nil {
+			t.Errorf("%q must be invalid", invalid)
+		}
+	}
+}
+
+func TestMemory(t *testing.T) {
+	m := NewMemory()
+	ctx, root := m.Start(context.Background(), "AutoCallContract", "contract", "@1TokensSend")
+	childCtx, child := m.Start(ctx, "SendTx")
+	h := make(http.Header)
+	Inject(childCtx, h)
+	err := errors.New("send failed")
+	End(child, &err)
+	root.End()
+	root.End()
+
+	spans := m.Spans()
+	if len(spans) != 2 || spans[0].Name != "SendTx" || spans[1].Name != "AutoCallContract" {
+		t.Fatalf("unexpected spans %+v", spans)
+	}
+	if spans[0].SpanContext.TraceID != spans[1].SpanContext.TraceID || spans[0].ParentID != spans[1].SpanContext.SpanID ||
+		spans[1].ParentID != [8]byte{} {
+		t.Error("SendTx must be a child of AutoCallContract")
+	}
+	if spans[0].Err != err || spans[1].Attributes["contract"] != "@1TokensSend" {
+		t.Errorf("unexpected span data %+v", spans)
+	}
+	if got := h.Get(TraceParentHeader); got != spans[0].SpanContext.TraceParent() {
+		t.Errorf("want the traceparent of SendTx, got %s", got)
+	}
+
+	h = make(http.Header)
+	ctx, span := Nop.Start(context.Background(), "GetList")
+	Inject(ctx, h)
+	span.End()
+	if len(h) != 0 {
+		t.Errorf("the Nop tracer must not send a trace context, got %v", h)
+	}
+}
@@ -0,0 +1,182 @@
+package transport
+
+import (
+	"context"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/utils"
+	"math"
+	"sync"
+	"time"
+)
+
+// Limit is the request budget of a node endpoint, a zero field disables its check
+type Limit struct {
+	Rate        float64 // requests per second, the refill rate of the token bucket
+	Burst       int     // size of the token bucket, Rate rounded up if zero
+	MaxInFlight int     // max concurrent requests
+}
+
+// WithReadLimit limits the read-only requests sent to every node endpoint
+func WithReadLimit(l Limit) Option {
+	return func(o *Options) {
+		o.ReadLimit = &l
+	}
+}
+
+// WithSendTxLimit limits the requests sending transactions to every node endpoint,
+// they do not use the budget of the read-only requests
+func WithSendTxLimit(l Limit) Option {
+	return func(o *Options) {
+		o.SendTxLimit = &l
+	}
+}
+
+// limiter enforces the read and sendTx budgets per endpoint
+type limiter struct {
+	read    *Limit
+	sendTx  *Limit
+	lock    sync.Mutex
+	budgets map[budgetKey]*budget
+}
+
+type budgetKey struct {
+	endpoint string
+	sendTx   bool
+}
+
+type budget struct {
+	bucket *bucket
+	slots  chan struct{}
+}
+
+func newLimiter(read, sendTx *Limit) *limiter {
+	if read == nil && sendTx == nil {
+		return nil
+	}
+	return &limiter{read: read, sendTx: sendTx, budgets: make(map[budgetKey]*budget)}
+}
+
+// acquire waits for the budget of endpoint, release must be called when the request is done
+func (l *limiter) acquire(ctx context.Context, endpoint string, sendTx bool) (release func(), err error) {
+	release = func() {}
+	if l == nil {
+		return
+	}
+	b := l.budget(endpoint, sendTx)
+	if b == nil {
+		return
+	}
+	if b.slots != nil {
+		select {
+		case b.slots <- struct{}{}:
+			release = func() { <-b.slots }
+		case <-ctx.Done():
+			return release, ctx.Err()
+		}
+	}
+	if b.bucket != nil {
+		if err = b.bucket.wait(ctx); err != nil {
+			release()
+			return func() {}, err
+		}
+	}
+	return
+}
+
+func (l *limiter) budget(endpoint string, sendTx bool) *budget {
+	limit := l.read
+	if sendTx {
+		limit = l.sendTx
+	}
+	if limit == nil {
+		return nil
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	key := budgetKey{endpoint: endpoint, sendTx: sendTx}
+	b, ok := l.budgets[key]
+	if !ok {
+		b = &budget{}
+		if limit.Rate > 0 {
+			b.bucket = newBucket(limit.Rate, limit.Burst)
+		}
+		if limit.MaxInFlight > 0 {
+			b.slots = make(chan struct{}, limit.MaxInFlight)
+		}
+		l.budgets[key] = b
+	}
+	return b
+}
+
+// bucket is a token bucket, it starts full
+type bucket struct {
+	lock   sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(rate float64, burst int) *bucket {
+	size := float64(burst)
+	if burst <= 0 {
+		size = math.Ceil(rate)
+	}
+	return &bucket{rate: rate, burst: size, tokens: size, last: time.Now()}
+}
+
+// wait takes a token, the token is reserved first so the waiters are served in turn.
+// If ctx is done before the token is available, the reservation is given back
+func (b *bucket) wait(ctx context.Context) error {
+	b.lock.Lock()
+	now := time.Now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+	var delay time.Duration
+	if b.tokens < 0 {
+		delay = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+	b.lock.Unlock()
+	if delay == 0 {
+		return nil
+	}
+	if err := utils.Sleep(ctx, delay); err != nil {
+		b.lock.Lock()
+		b.tokens++
+		b.lock.Unlock()
+		return err
+	}
+	return nil
+}
+
+// limited calls send within the budget of endpoint
+func (t *Transport) limited(ctx context.Context, endpoint string, sendTx bool, send func(endpoint string) error) error {
+	release, err := t.limiter.acquire(ctx, endpoint, sendTx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	start := time.Now()
+	err = send(endpoint)
+	t.metrics.ObserveRequest(callMethod(ctx), t.endpoint(endpoint), time.Since(start), unwrapTemporary(err))
+	return err
+}
+
+// SendOnce calls send once without retry, within the read or sendTx budget of the endpoint picked from the pool.
+// It is used for the requests that can not be confirmed before sending again, only a request rejected
+// for its token is sent again after logging in again
+func (t *Transport) SendOnce(ctx context.Context, sendTx bool, send func(endpoint string) error) error {
+	var endpoint string
+	if t.pool != nil {
+		endpoint = t.pool.Pick()
+	}
+	gen := t.session()
+	err := t.limited(ctx, endpoint, sendTx, send)
+	if t.canReauth(ctx, err) {
+		if er := t.loginAgain(ctx, endpoint, gen, err); er != nil {
+			return er
+		}
+		err = t.limited(ctx, endpoint, sendTx, send)
+	}
+	return err
+}
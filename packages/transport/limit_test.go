@@ -0,0 +1,123 @@
+package transport
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTransport_ReadLimitRate(t *testing.T) {
+	tr := New(NewOptions(WithReadLimit(Limit{Rate: 50, Burst: 1})))
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := tr.Retry(context.Background(), func(string) error { return nil }); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the first request takes the burst, the other 3 wait 20ms each
+	if d := time.Since(start); d < 50*time.Millisecond {
+		t.Errorf("want the requests delayed by the rate, took %s", d)
+	}
+}
+
+func TestTransport_MaxInFlight(t *testing.T) {
+	tr := New(NewOptions(WithReadLimit(Limit{MaxInFlight: 2})))
+	var (
+		wg            sync.WaitGroup
+		inFlight, max int32
+	)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = tr.Retry(context.Background(), func(string) error {
+				n := atomic.AddInt32(&inFlight, 1)
+				for {
+					m := atomic.LoadInt32(&max)
+					if n <= m || atomic.CompareAndSwapInt32(&max, m, n) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				atomic.AddInt32(&inFlight, -1)
+				return nil
+			})
+		}()
+	}
+	wg.Wait()
+	if max != 2 {
+		t.Errorf("want at most 2 requests in flight, got %d", max)
+	}
+}
+
+func TestTransport_LimitCanceled(t *testing.T) {
+	tr := New(NewOptions(WithReadLimit(Limit{Rate: 0.1, MaxInFlight: 1})))
+	if err := tr.Retry(context.Background(), func(string) error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	var calls int
+	start := time.Now()
+	err := tr.Retry(ctx, func(string) error {
+		calls++
+		return nil
+	})
+	if err != context.DeadlineExceeded || calls != 0 {
+		t.Errorf("want the deadline error without sending, got %d calls, err %v", calls, err)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("the wait must end with the context, took %s", d)
+	}
+
+	// the slot is released after the canceled wait
+	block := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- tr.Retry(context.Background(), func(string) error {
+			<-block
+			return nil
+		})
+	}()
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err = tr.Retry(ctx, func(string) error { return nil }); err != context.DeadlineExceeded {
+		t.Errorf("want the deadline error waiting for the slot, got %v", err)
+	}
+	close(block)
+	<-done
+}
+
+func TestTransport_SendTxLimit(t *testing.T) {
+	tr := New(NewOptions(WithReadLimit(Limit{MaxInFlight: 1}), WithSendTxLimit(Limit{MaxInFlight: 1})))
+	block := make(chan struct{})
+	sending := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- tr.RetryTx(context.Background(), func(string) error {
+			close(sending)
+			<-block
+			return nil
+		}, nil)
+	}()
+	<-sending
+
+	// reads do not share the budget of sendTx
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := tr.Retry(ctx, func(string) error { return nil }); err != nil {
+		t.Errorf("read blocked by sendTx: %v", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := tr.SendOnce(ctx, true, func(string) error { return nil }); err != context.DeadlineExceeded {
+		t.Errorf("want the deadline error waiting for the sendTx slot, got %v", err)
+	}
+	close(block)
+	if err := <-done; err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,75 @@
+package transport
+
+import (
+	"bytes"
+	"github.com/IBAX-io/go-ibax-sdk/packages/logger"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+)
+
+// maxLoggedBody is the size of the bodies logged at the debug level, the rest is cut
+const maxLoggedBody = 4 << 10
+
+// WithLogger sets the logger of the client. The requests and responses are logged at the debug level
+// without the tokens, signatures and transaction data, the retries and failovers at the warn level
+func WithLogger(l logger.Logger) Option {
+	return func(o *Options) {
+		o.Logger = l
+	}
+}
+
+// Logger returns the logger of the client, logger.Nop if it is not set
+func (t *Transport) Logger() logger.Logger {
+	return t.logger
+}
+
+func (t *Transport) debugRequest(req *http.Request) {
+	var body []byte
+	if req.GetBody != nil {
+		if r, err := req.GetBody(); err == nil {
+			body, _ = io.ReadAll(r)
+			r.Close()
+		}
+	}
+	t.logger.Debug("send request", "method", req.Method, "url", req.URL.String(),
+		"header", logger.RedactHeader(req.Header), "body", cutBody(logger.RedactBody(req.Header.Get("Content-Type"), body)))
+}
+
+// debugResponse logs resp, a JSON body is read and set again to resp, the files are not read
+func (t *Transport) debugResponse(req *http.Request, resp *http.Response, err error, elapsed time.Duration) {
+	if err != nil {
+		t.logger.Debug("request failed", "method", req.Method, "url", req.URL.String(), "elapsed", elapsed, "error", err)
+		return
+	}
+	contentType := resp.Header.Get("Content-Type")
+	keyvals := []any{"method", req.Method, "url", req.URL.String(), "status", resp.StatusCode,
+		"content_type", contentType, "elapsed", elapsed}
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType == "application/json" {
+		data, rerr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(io.MultiReader(bytes.NewReader(data), errReader{rerr}))
+		keyvals = append(keyvals, "body", cutBody(logger.RedactBody(contentType, data)))
+	}
+	t.logger.Debug("receive response", keyvals...)
+}
+
+// errReader returns err, or io.EOF if err is nil
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	if r.err == nil {
+		return 0, io.EOF
+	}
+	return 0, r.err
+}
+
+func cutBody(body string) string {
+	if len(body) > maxLoggedBody {
+		return body[:maxLoggedBody] + "..."
+	}
+	return body
+}
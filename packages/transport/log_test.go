@@ -0,0 +1,62 @@
+package transport
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type recordLogger struct {
+	lock  sync.Mutex
+	lines []string
+}
+
+func (r *recordLogger) log(level, msg string, keyvals ...any) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.lines = append(r.lines, fmt.Sprint(level, " ", msg, " ", keyvals))
+}
+
+func (r *recordLogger) Debug(msg string, keyvals ...any) { r.log("debug", msg, keyvals...) }
+func (r *recordLogger) Info(msg string, keyvals ...any)  { r.log("info", msg, keyvals...) }
+func (r *recordLogger) Warn(msg string, keyvals ...any)  { r.log("warn", msg, keyvals...) }
+func (r *recordLogger) Error(msg string, keyvals ...any) { r.log("error", msg, keyvals...) }
+
+func TestTransport_Logger(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"token":"response-secret","ecosystem_id":"1"}`)
+	}))
+	defer srv.Close()
+
+	l := &recordLogger{}
+	tr := New(NewOptions(WithLogger(l)))
+	req, err := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader("signature=request-secret&pubkey=04"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", "Bearer header-secret")
+	resp, err := tr.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil || !strings.Contains(string(body), "response-secret") {
+		t.Fatalf("the response body must be read again, got %s %v", body, err)
+	}
+
+	if len(l.lines) != 2 || !strings.HasPrefix(l.lines[0], "debug send request") ||
+		!strings.HasPrefix(l.lines[1], "debug receive response") {
+		t.Fatalf("unexpected logs %q", l.lines)
+	}
+	logs := strings.Join(l.lines, "\n")
+	if strings.Contains(logs, "secret") || !strings.Contains(logs, "pubkey=04") || !strings.Contains(logs, "ecosystem_id") {
+		t.Errorf("the secrets must be redacted, got %s", logs)
+	}
+}
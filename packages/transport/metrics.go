@@ -0,0 +1,43 @@
+package transport
+
+import (
+	"context"
+	"github.com/IBAX-io/go-ibax-sdk/packages/metrics"
+	"strings"
+)
+
+// WithMetrics sets the metrics of the client, the attempts, retries and logins again are measured
+// by the method and the node endpoint, the transactions by the tx packages, see metrics.Registry
+func WithMetrics(m metrics.Metrics) Option {
+	return func(o *Options) {
+		o.Metrics = m
+	}
+}
+
+// Metrics returns the metrics of the client, metrics.Nop if it is not set
+func (t *Transport) Metrics() metrics.Metrics {
+	return t.metrics
+}
+
+// callMethod names the call of ctx in the metrics, the first segment of a RESTful api path,
+// so the ids in the path do not create a series each
+func callMethod(ctx context.Context) string {
+	call, _ := ctx.Value(callKey{}).(*Call)
+	if call == nil {
+		return ""
+	}
+	if call.Backend == BackendRPC {
+		return call.Name
+	}
+	name, _, _ := strings.Cut(strings.TrimPrefix(call.Name, "/"), "?")
+	name, _, _ = strings.Cut(name, "/")
+	return name
+}
+
+// endpoint names endpoint in the metrics, the api address if no pool is set
+func (t *Transport) endpoint(endpoint string) string {
+	if endpoint == "" {
+		return t.address
+	}
+	return endpoint
+}
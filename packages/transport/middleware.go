@@ -0,0 +1,62 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+)
+
+// Backend names of Call
+const (
+	BackendREST = "rest"
+	BackendRPC  = "rpc"
+)
+
+// Call describes a request of the RESTful api or JSON-RPC backend seen by the middlewares,
+// only the changes of Header are used to send the request
+type Call struct {
+	Backend string      // BackendREST or BackendRPC
+	Method  string      // http method
+	Name    string      // url path of the RESTful api, JSON-RPC method name, or "batch"
+	Params  any         // *url.Values or map[string][]byte of a multipart request, request.Params or []request.BatchRequest
+	Header  http.Header // extra headers of the request, set by the middlewares
+	Result  any         // pointer receiving the decoded result, filled when the handler returns
+}
+
+// Handler sends call
+type Handler func(ctx context.Context, call *Call) error
+
+// Middleware wraps the handler of every call, it runs once per call around the retries
+type Middleware func(next Handler) Handler
+
+// WithMiddleware appends middlewares, the first one is the outermost
+func WithMiddleware(m ...Middleware) Option {
+	return func(o *Options) {
+		o.Middlewares = append(o.Middlewares, m...)
+	}
+}
+
+type headerKey struct{}
+
+type callKey struct{}
+
+// Intercept passes call through the middlewares to send. The headers set by the middlewares are
+// added to the requests sent by Do with the ctx given to send
+func (t *Transport) Intercept(ctx context.Context, call *Call, send Handler) error {
+	ctx = context.WithValue(ctx, callKey{}, call)
+	h := func(ctx context.Context, call *Call) error {
+		if len(call.Header) > 0 {
+			ctx = context.WithValue(ctx, headerKey{}, call.Header)
+		}
+		return send(ctx, call)
+	}
+	for i := len(t.middlewares) - 1; i >= 0; i-- {
+		h = t.middlewares[i](h)
+	}
+	return h(ctx, call)
+}
+
+// callHeader returns the headers set by the middlewares
+func callHeader(ctx context.Context) http.Header {
+	h, _ := ctx.Value(headerKey{}).(http.Header)
+	return h
+}
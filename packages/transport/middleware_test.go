@@ -0,0 +1,57 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTransport_Intercept(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Header.Get("X-Request-Id")))
+	}))
+	defer srv.Close()
+
+	var order []string
+	trace := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, call *Call) error {
+				order = append(order, name)
+				if call.Header == nil {
+					call.Header = make(http.Header)
+				}
+				call.Header.Set("X-Request-Id", name)
+				return next(ctx, call)
+			}
+		}
+	}
+	tr := New(NewOptions(WithMiddleware(trace("outer"), trace("inner"))))
+
+	var body string
+	call := &Call{Backend: BackendREST, Method: http.MethodGet, Name: "version", Result: &body}
+	err := tr.Intercept(context.Background(), call, func(ctx context.Context, call *Call) error {
+		req, err := http.NewRequestWithContext(ctx, call.Method, srv.URL+"/"+call.Name, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := tr.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		buf := make([]byte, 16)
+		n, _ := resp.Body.Read(buf)
+		*call.Result.(*string) = string(buf[:n])
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(order) != 2 || order[0] != "outer" || order[1] != "inner" {
+		t.Errorf("want outer before inner, got %v", order)
+	}
+	if body != "inner" {
+		t.Errorf("want the header set by the middlewares, got %q", body)
+	}
+}
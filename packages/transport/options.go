@@ -0,0 +1,86 @@
+package transport
+
+import (
+	"github.com/IBAX-io/go-ibax-sdk/packages/logger"
+	"github.com/IBAX-io/go-ibax-sdk/packages/metrics"
+	"github.com/IBAX-io/go-ibax-sdk/packages/tracing"
+	"net/http"
+	"time"
+)
+
+// Options is the transport configuration shared by the RESTful api and JSON-RPC backends
+type Options struct {
+	HTTPClient   *http.Client      // http client used to send requests, if nil a new client is created
+	RoundTripper http.RoundTripper // replaces the Transport of HTTPClient if not nil
+	Timeout      time.Duration     // replaces the Timeout of HTTPClient if greater than zero
+	UserAgent    string            // User-Agent header of every request
+	Header       http.Header       // extra headers of every request
+	Retry        *RetryPolicy      // retry policy of failed requests, nil disables retry
+
+	HealthCheckInterval time.Duration   // probe interval of the node endpoints, see Pool
+	MaxBlockLag         int64           // allowed max block id lag of a node endpoint, see Pool
+	KeepAlive           time.Duration   // ping interval of the websocket connections, see WebSocket
+	Reauth              bool            // log in again when the token is rejected, see WithReauth
+	Middlewares         []Middleware    // wrap every call, see WithMiddleware
+	ReadLimit           *Limit          // budget of the read-only requests per node endpoint, nil is unlimited
+	SendTxLimit         *Limit          // budget of the requests sending transactions per node endpoint, nil is unlimited
+	Logger              logger.Logger   // logs of the client, nil discards them, see WithLogger
+	Metrics             metrics.Metrics // measures of the client, nil discards them, see WithMetrics
+	Tracer              tracing.Tracer  // spans of the client, nil records none, see WithTracer
+	RefreshAhead        time.Duration   // log in again this long before the token expiry, zero disables it, see WithTokenRefresh
+
+	OnTokenRefreshed func(expire time.Time) // called after a successful login again, see OnTokenRefreshed
+	OnAuthFailed     func(err error)        // called after a failed login again, see OnAuthFailed
+}
+
+// Option configures Options
+type Option func(*Options)
+
+// NewOptions returns the Options after applying opts in order
+func NewOptions(opts ...Option) *Options {
+	o := &Options{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+	return o
+}
+
+// WithHTTPClient use c to send requests
+func WithHTTPClient(c *http.Client) Option {
+	return func(o *Options) {
+		o.HTTPClient = c
+	}
+}
+
+// WithRoundTripper use rt as the transport of the http client
+func WithRoundTripper(rt http.RoundTripper) Option {
+	return func(o *Options) {
+		o.RoundTripper = rt
+	}
+}
+
+// WithTimeout set the time limit for every request
+func WithTimeout(d time.Duration) Option {
+	return func(o *Options) {
+		o.Timeout = d
+	}
+}
+
+// WithUserAgent set the User-Agent header
+func WithUserAgent(ua string) Option {
+	return func(o *Options) {
+		o.UserAgent = ua
+	}
+}
+
+// WithHeader add an extra header to every request
+func WithHeader(key, value string) Option {
+	return func(o *Options) {
+		if o.Header == nil {
+			o.Header = make(http.Header)
+		}
+		o.Header.Add(key, value)
+	}
+}
@@ -0,0 +1,197 @@
+package transport
+
+import (
+	"context"
+	"github.com/IBAX-io/go-ibax-sdk/packages/logger"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ProbeFunc returns the max block id of the node at endpoint
+type ProbeFunc func(ctx context.Context, endpoint string) (int64, error)
+
+// EndpointStatus is the health of a node endpoint
+type EndpointStatus struct {
+	Endpoint   string
+	Healthy    bool
+	MaxBlockID int64
+	LastCheck  time.Time
+	LastError  error
+}
+
+// Pool routes requests to healthy node endpoints. The endpoints are probed at most once per
+// health check interval, triggered by the requests, an endpoint is unhealthy if the probe fails,
+// a request to it fails with a temporary error, or its max block id lags behind the highest one
+// by more than the max block lag
+type Pool struct {
+	lock        sync.RWMutex
+	endpoints   []*EndpointStatus
+	probe       ProbeFunc
+	interval    time.Duration
+	maxBlockLag int64
+	lastCheck   time.Time
+	checking    int32
+	next        uint32
+	logger      logger.Logger
+}
+
+// Default health check settings of Pool
+const (
+	DefaultHealthCheckInterval = 30 * time.Second
+	DefaultProbeTimeout        = 5 * time.Second
+)
+
+// WithHealthCheck set the probe interval and the allowed block lag of a node endpoint pool,
+// a zero maxBlockLag does not check the lag
+func WithHealthCheck(interval time.Duration, maxBlockLag int64) Option {
+	return func(o *Options) {
+		o.HealthCheckInterval = interval
+		o.MaxBlockLag = maxBlockLag
+	}
+}
+
+// NewPool creates a Pool of endpoints, all endpoints are considered healthy until probed
+func NewPool(endpoints []string, probe ProbeFunc, opts *Options) *Pool {
+	p := &Pool{probe: probe, interval: DefaultHealthCheckInterval, logger: logger.Nop}
+	if opts != nil {
+		p.logger = logger.OrNop(opts.Logger)
+		if opts.HealthCheckInterval > 0 {
+			p.interval = opts.HealthCheckInterval
+		}
+		p.maxBlockLag = opts.MaxBlockLag
+	}
+	for _, v := range endpoints {
+		p.endpoints = append(p.endpoints, &EndpointStatus{Endpoint: v, Healthy: true})
+	}
+	// the first check runs on the first request
+	p.lastCheck = time.Now().Add(-p.interval)
+	return p
+}
+
+// Len returns the number of endpoints
+func (p *Pool) Len() int {
+	return len(p.endpoints)
+}
+
+// Pick returns the next healthy endpoint in turn, if no endpoint is healthy, the next one in turn
+func (p *Pool) Pick() string {
+	p.checkLater()
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	n := len(p.endpoints)
+	start := int(atomic.AddUint32(&p.next, 1)-1) % n
+	for i := 0; i < n; i++ {
+		e := p.endpoints[(start+i)%n]
+		if e.Healthy {
+			return e.Endpoint
+		}
+	}
+	return p.endpoints[start].Endpoint
+}
+
+// Fail marks endpoint unhealthy until the next successful probe
+func (p *Pool) Fail(endpoint string, err error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for _, e := range p.endpoints {
+		if e.Endpoint == endpoint {
+			if e.Healthy {
+				p.logger.Warn("node endpoint unhealthy", "endpoint", endpoint, "error", err)
+			}
+			e.Healthy = false
+			e.LastError = err
+		}
+	}
+}
+
+// Status returns a copy of the endpoints health
+func (p *Pool) Status() []EndpointStatus {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	list := make([]EndpointStatus, len(p.endpoints))
+	for k, e := range p.endpoints {
+		list[k] = *e
+	}
+	return list
+}
+
+// Check probes all endpoints and updates their health
+func (p *Pool) Check(ctx context.Context) {
+	type result struct {
+		maxBlockID int64
+		err        error
+	}
+	results := make([]result, len(p.endpoints))
+	var wg sync.WaitGroup
+	for k, e := range p.endpoints {
+		wg.Add(1)
+		go func(k int, endpoint string) {
+			defer wg.Done()
+			pctx, cancel := context.WithTimeout(ctx, DefaultProbeTimeout)
+			defer cancel()
+			results[k].maxBlockID, results[k].err = p.probe(pctx, endpoint)
+		}(k, e.Endpoint)
+	}
+	wg.Wait()
+
+	var highest int64
+	for _, r := range results {
+		if r.err == nil && r.maxBlockID > highest {
+			highest = r.maxBlockID
+		}
+	}
+	now := time.Now()
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.lastCheck = now
+	for k, e := range p.endpoints {
+		r := results[k]
+		healthy := e.Healthy
+		e.LastCheck = now
+		e.LastError = r.err
+		if r.err != nil {
+			e.Healthy = false
+		} else {
+			e.MaxBlockID = r.maxBlockID
+			e.Healthy = p.maxBlockLag <= 0 || highest-r.maxBlockID <= p.maxBlockLag
+		}
+		switch {
+		case healthy && !e.Healthy:
+			p.logger.Warn("node endpoint unhealthy", "endpoint", e.Endpoint, "max_block_id", e.MaxBlockID,
+				"highest_block_id", highest, "error", r.err)
+		case !healthy && e.Healthy:
+			p.logger.Info("node endpoint healthy again", "endpoint", e.Endpoint, "max_block_id", e.MaxBlockID)
+		}
+	}
+}
+
+// checkLater starts a check in background if the last one is older than the interval
+func (p *Pool) checkLater() {
+	if p.probe == nil {
+		return
+	}
+	p.lock.RLock()
+	due := time.Since(p.lastCheck) >= p.interval
+	p.lock.RUnlock()
+	if !due || !atomic.CompareAndSwapInt32(&p.checking, 0, 1) {
+		return
+	}
+	go func() {
+		defer atomic.StoreInt32(&p.checking, 0)
+		p.Check(context.Background())
+	}()
+}
+
+// healthy returns the number of healthy endpoints
+func (p *Pool) healthy() int {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	var n int
+	for _, e := range p.endpoints {
+		if e.Healthy {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,61 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestPool_Check(t *testing.T) {
+	blocks := map[string]int64{"a": 100, "b": 95, "c": 0}
+	probe := func(ctx context.Context, endpoint string) (int64, error) {
+		if endpoint == "c" {
+			return 0, errors.New("connection refused")
+		}
+		return blocks[endpoint], nil
+	}
+	p := NewPool([]string{"a", "b", "c"}, probe, NewOptions(WithHealthCheck(DefaultHealthCheckInterval, 3)))
+	p.Check(context.Background())
+
+	want := map[string]bool{"a": true, "b": false, "c": false}
+	for _, s := range p.Status() {
+		if s.Healthy != want[s.Endpoint] {
+			t.Errorf("endpoint %s: want healthy %v, got %v", s.Endpoint, want[s.Endpoint], s.Healthy)
+		}
+	}
+	for i := 0; i < 3; i++ {
+		if e := p.Pick(); e != "a" {
+			t.Errorf("want the only healthy endpoint a, got %s", e)
+		}
+	}
+}
+
+func TestTransport_Failover(t *testing.T) {
+	tr := newRetryTransport()
+	tr.SetPool(NewPool([]string{"a", "b"}, nil, nil))
+
+	var sent []string
+	err := tr.Retry(context.Background(), func(endpoint string) error {
+		sent = append(sent, endpoint)
+		if endpoint == "a" {
+			return Temporary(errors.New("502 Bad Gateway"))
+		}
+		return nil
+	})
+	if err != nil || len(sent) != 2 || sent[1] != "b" {
+		t.Errorf("want failover from a to b, got %v, err %v", sent, err)
+	}
+
+	var checked string
+	sent = nil
+	err = tr.RetryTx(context.Background(), func(endpoint string) error {
+		sent = append(sent, endpoint)
+		return Temporary(errors.New("503 Service Unavailable"))
+	}, func(endpoint string) (bool, error) {
+		checked = endpoint
+		return false, nil
+	})
+	if err != nil || len(sent) != 1 || checked != sent[0] {
+		t.Errorf("txstatus must be checked on the failed endpoint %v, got %s, err %v", sent, checked, err)
+	}
+}
@@ -0,0 +1,86 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/IBAX-io/go-ibax-sdk/packages/response"
+	"sync"
+	"sync/atomic"
+)
+
+// WithReauth enables the transparent re-authentication, a request failed with response.ErrUnauthorized
+// logs in again once and is sent again. The login is shared by the requests failed at the same time
+func WithReauth() Option {
+	return func(o *Options) {
+		o.Reauth = true
+	}
+}
+
+type reauthKey struct{}
+
+// reauth runs the login flow of a client under a single-flight guard
+type reauth struct {
+	lock  sync.Mutex
+	login func(ctx context.Context) error
+	gen   uint64 // incremented by every successful login
+}
+
+// SetReauth sets the login flow used by WithReauth, it is called by the client constructors
+func (t *Transport) SetReauth(login func(ctx context.Context) error) {
+	t.reauth.lock.Lock()
+	defer t.reauth.lock.Unlock()
+	t.reauth.login = login
+}
+
+// canReauth reports whether err can be fixed by logging in again,
+// the requests sent by the login flow itself are never re-authenticated
+func (t *Transport) canReauth(ctx context.Context, err error) bool {
+	return t.reauthEnabled && ctx.Value(reauthKey{}) == nil && errors.Is(err, response.ErrUnauthorized)
+}
+
+func (t *Transport) session() uint64 {
+	return atomic.LoadUint64(&t.reauth.gen)
+}
+
+// relogin logs in again unless another request has already done it since session gen was used
+func (t *Transport) relogin(ctx context.Context, gen uint64) error {
+	t.reauth.lock.Lock()
+	if t.session() != gen {
+		t.reauth.lock.Unlock()
+		return nil
+	}
+	err := t.login(ctx)
+	t.reauth.lock.Unlock()
+	t.loggedIn(err)
+	return err
+}
+
+// loginAgain logs in again after the token of session gen is rejected by err at endpoint,
+// the rejected request is sent again if it returns nil
+func (t *Transport) loginAgain(ctx context.Context, endpoint string, gen uint64, err error) error {
+	// the node rejects the request before handling it, it is safe to send again
+	t.logger.Info("token rejected, logging in again", "endpoint", endpoint)
+	if er := t.relogin(ctx, gen); er != nil {
+		t.logger.Error("login again failed", "endpoint", endpoint, "error", er)
+		return reloginFailed(unwrapTemporary(err), er)
+	}
+	return nil
+}
+
+// login runs the login flow and starts a new session, the reauth lock is held
+func (t *Transport) login(ctx context.Context) error {
+	if t.reauth.login == nil {
+		return errors.New("login flow is not set")
+	}
+	err := t.reauth.login(context.WithValue(ctx, reauthKey{}, true))
+	if err != nil {
+		return err
+	}
+	atomic.AddUint64(&t.reauth.gen, 1)
+	return nil
+}
+
+func reloginFailed(err, loginErr error) error {
+	return fmt.Errorf("%w, login again failed:%s", err, loginErr.Error())
+}
@@ -0,0 +1,79 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"github.com/IBAX-io/go-ibax-sdk/packages/response"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTransport_Reauth(t *testing.T) {
+	tr := New(NewOptions(WithReauth()))
+	var (
+		token  int32
+		logins int32
+	)
+	tr.SetReauth(func(ctx context.Context) error {
+		atomic.AddInt32(&logins, 1)
+		// the requests of the login flow are not re-authenticated
+		err := tr.Retry(ctx, func(string) error {
+			return response.NewHTTPError(401, nil)
+		})
+		if !errors.Is(err, response.ErrUnauthorized) {
+			t.Errorf("want the login request to fail, got %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.StoreInt32(&token, 1)
+		return nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := tr.Retry(context.Background(), func(string) error {
+				if atomic.LoadInt32(&token) == 0 {
+					return &response.RPCError{Code: response.RPCCodeUnauthorized, Message: "Unauthorized"}
+				}
+				return nil
+			})
+			if err != nil {
+				t.Errorf("want success after login again, got %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if logins != 1 {
+		t.Errorf("want 1 login shared by the requests, got %d", logins)
+	}
+
+	// a request sent once, such as sendTx, is sent again after login only when its token is rejected
+	atomic.StoreInt32(&token, 0)
+	var sends int
+	err := tr.SendOnce(context.Background(), true, func(string) error {
+		sends++
+		if atomic.LoadInt32(&token) == 0 {
+			return response.NewHTTPError(401, nil)
+		}
+		return Temporary(response.NewHTTPError(503, nil))
+	})
+	if !IsTemporary(err) || sends != 2 || logins != 2 {
+		t.Errorf("want the request sent again once after login, got %d sends, %d logins, %v", sends, logins, err)
+	}
+
+	tr = New(NewOptions())
+	tr.SetReauth(func(ctx context.Context) error {
+		t.Error("re-authentication is opt-in")
+		return nil
+	})
+	err = tr.Retry(context.Background(), func(string) error {
+		return response.NewHTTPError(401, nil)
+	})
+	if !errors.Is(err, response.ErrUnauthorized) {
+		t.Errorf("want ErrUnauthorized, got %v", err)
+	}
+}
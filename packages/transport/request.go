@@ -0,0 +1,300 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/IBAX-io/go-ibax-sdk/packages/request"
+	"github.com/IBAX-io/go-ibax-sdk/packages/response"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// The RESTful and the JSON-RPC bases build the requests to the api of the node, and decode its responses, by
+// SendForm, SendFiles and SendMessage. Each request is sent to a Target, built by the base for the endpoint picked
+// by Retry, RetryTx or SendOnce
+
+// Target is where a request to the api of a node is sent
+type Target struct {
+	Method string
+	URL    string
+	// Authorization is the value of the Authorization header, not set if empty
+	Authorization string
+	// Unauthorized is called if the node rejects the request with the status 401
+	Unauthorized func()
+}
+
+// SendForm sends form url encoded to target, the response is decoded into result by its content type,
+// or streamed into a *response.FileStream
+func (t *Transport) SendForm(ctx context.Context, target Target, form *url.Values, result any) error {
+	var body []byte
+	if form != nil {
+		body = []byte(form.Encode())
+	}
+	return t.sendREST(ctx, target, "application/x-www-form-urlencoded", body, result)
+}
+
+// SendFiles sends files as a multipart form to target, each file is named by its key
+func (t *Transport) SendFiles(ctx context.Context, target Target, files map[string][]byte, result any) error {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	for key, data := range files {
+		part, err := writer.CreateFormFile(key, key)
+		if err != nil {
+			return err
+		}
+		if _, err = part.Write(data); err != nil {
+			return err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	return t.sendREST(ctx, target, writer.FormDataContentType(), body.Bytes(), result)
+}
+
+func (t *Transport) sendREST(ctx context.Context, target Target, contentType string, body []byte, result any) error {
+	resp, err := t.send(ctx, target, contentType, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if stream, ok := result.(*response.FileStream); ok {
+		return stream.Copy(resp.Header.Get("Content-Type"), resp.ContentLength, resp.Body)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	return response.DecodeBody(resp.Header.Get("Content-Type"), data, result)
+}
+
+// SendMessage sends the JSON-RPC message msg, a request.Request or a []request.BatchRequest, to target or over
+// the websocket connection to its url. The result of a batch is set to its requests, the result of a single
+// request is decoded into result, or saved as a file into a *request.FileType or a *response.FileStream
+func (t *Transport) SendMessage(ctx context.Context, target Target, msg any, result any) error {
+	var (
+		body    []byte
+		err     error
+		isBatch bool
+	)
+	switch m := msg.(type) {
+	case request.Request:
+		body, err = json.Marshal(m)
+	case []request.BatchRequest:
+		isBatch = true
+		batchRequest := make([]request.Request, len(m))
+		for k, v := range m {
+			batchRequest[k] = *v.Req
+		}
+		body, err = json.Marshal(batchRequest)
+	default:
+		return fmt.Errorf("the request structure does not support")
+	}
+	if err != nil {
+		return err
+	}
+	if IsWebSocket(target.URL) {
+		return t.sendWebSocket(ctx, target, msg, isBatch, body, result)
+	}
+
+	resp, err := t.send(ctx, target, "application/json", body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	contentType := resp.Header.Get("Content-Type")
+	if stream, ok := result.(*response.FileStream); ok {
+		return t.copyFile(msg, contentType, resp, stream)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if _, ok := result.(*request.FileType); ok || !strings.Contains(contentType, "application/json") {
+		return response.DecodeBody(contentType, data, result)
+	}
+	return t.decodeMessage(msg, isBatch, data, result)
+}
+
+// sendWebSocket sends body over the websocket connection to the url of target, file responses are not supported
+func (t *Transport) sendWebSocket(ctx context.Context, target Target, msg any, isBatch bool, body []byte, result any) error {
+	switch result.(type) {
+	case *request.FileType, *response.FileStream:
+		return fmt.Errorf("file response is not supported by websocket")
+	}
+	var ids []string
+	switch m := msg.(type) {
+	case request.Request:
+		id, err := json.Marshal(m.ID)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, string(id))
+	case []request.BatchRequest:
+		for _, v := range m {
+			id, err := json.Marshal(v.Req.ID)
+			if err != nil {
+				return err
+			}
+			ids = append(ids, string(id))
+		}
+	}
+	data, err := t.WebSocket(target.URL).Call(ctx, ids, target.Authorization, body)
+	if err != nil {
+		return err
+	}
+	return t.decodeMessage(msg, isBatch, data, result)
+}
+
+// copyFile streams the file of resp into stream, the errors are sent by the node as JSON-RPC responses
+func (t *Transport) copyFile(msg any, contentType string, resp *http.Response, stream *response.FileStream) error {
+	if !strings.Contains(contentType, "application/json") {
+		return stream.Copy(contentType, resp.ContentLength, resp.Body)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	ret := &response.Response{}
+	if json.Unmarshal(data, ret) == nil && ret.Error != nil {
+		return t.decodeMessage(msg, false, data, nil)
+	}
+	return stream.Copy(contentType, int64(len(data)), bytes.NewReader(data))
+}
+
+// decodeMessage unmarshals the JSON-RPC response data of msg into result,
+// the results and errors of a batch are set to its requests
+func (t *Transport) decodeMessage(msg any, isBatch bool, data []byte, result any) error {
+	if isBatch {
+		ret := response.BatchResponse{}
+		if err := json.Unmarshal(data, &ret); err != nil {
+			return fmt.Errorf("json response decode failed:%s", err.Error())
+		}
+		return ret.SetResults(msg.([]request.BatchRequest))
+	}
+	ret := &response.Response{}
+	if err := json.Unmarshal(data, ret); err != nil {
+		return fmt.Errorf("json response decode failed:%s", err.Error())
+	}
+	if ret.Error != nil {
+		if t.RetryableRPCCode(ret.Error.Code) {
+			return Temporary(ret.Error)
+		}
+		return ret.Error
+	}
+	if len(ret.Result) == 0 {
+		return errors.New("not result in JSON-RPC response")
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(ret.Result, result)
+}
+
+// send sends body to target and returns the response of status 200, the other ones are returned as errors,
+// marked by Temporary if the retry policy retries their status
+func (t *Transport) send(ctx context.Context, target Target, contentType string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, target.Method, target.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	if target.Authorization != "" {
+		req.Header.Set("Authorization", target.Authorization)
+	}
+	resp, err := t.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusOK {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized && target.Unauthorized != nil {
+		target.Unauthorized()
+	}
+	data, _ := io.ReadAll(resp.Body)
+	err = response.NewHTTPError(resp.StatusCode, data)
+	if t.RetryableStatus(resp.StatusCode) {
+		return nil, Temporary(err)
+	}
+	return nil, err
+}
+
+// RetryMessage sends the JSON-RPC message msg by send with the retry policy. sendTx of a single transaction is sent
+// again only after absent confirms the node has not received it, the transactions sent together are not sent again
+func (t *Transport) RetryMessage(ctx context.Context, msg any, send func(endpoint string) error, absent func(endpoint string, hashes []string) (bool, error)) error {
+	switch m := msg.(type) {
+	case request.Request:
+		if m.Method == sendTxMethod {
+			hashes, err := txHashes(m)
+			if err != nil || len(hashes) != 1 {
+				//the node may have received some of the transactions, they can not be sent again together
+				return t.SendOnce(ctx, true, send)
+			}
+			return t.RetryTx(ctx, send, func(endpoint string) (bool, error) {
+				return absent(endpoint, hashes)
+			})
+		}
+	case []request.BatchRequest:
+		for _, v := range m {
+			if v.Req != nil && v.Req.Method == sendTxMethod {
+				//the transactions in a batch can not be confirmed one by one
+				return t.SendOnce(ctx, true, send)
+			}
+		}
+	}
+	return t.Retry(ctx, send)
+}
+
+// RetryFiles sends the files of a multipart request by send once, or by the retry policy for sendTx of a single
+// transaction, which is sent again only after absent confirms the node has not received it
+func (t *Transport) RetryFiles(ctx context.Context, sendTx bool, files map[string][]byte, send func(endpoint string) error, absent func(endpoint string, hashes []string) (bool, error)) error {
+	if !sendTx {
+		return t.SendOnce(ctx, false, send)
+	}
+	if len(files) != 1 {
+		//the node may have received some of the transactions, they can not be sent again together
+		return t.SendOnce(ctx, true, send)
+	}
+	hashes := make([]string, 0, len(files))
+	for hash := range files {
+		hashes = append(hashes, hash)
+	}
+	return t.RetryTx(ctx, send, func(endpoint string) (bool, error) {
+		return absent(endpoint, hashes)
+	})
+}
+
+// TxAbsent returns the result of the absent check of RetryMessage and RetryFiles from the error of the txStatus
+// request, the transactions not found by the node are absent
+func TxAbsent(err error) (bool, error) {
+	if err == nil {
+		return false, nil
+	}
+	if errors.Is(err, response.ErrNotFound) {
+		return true, nil
+	}
+	return false, err
+}
+
+const sendTxMethod = string(request.NamespaceIBAX) + request.NamespaceSeparator + "sendTx"
+
+// txHashes returns the hashes of the transactions sent by req
+func txHashes(req request.Request) ([]string, error) {
+	var txs map[string]json.RawMessage
+	if err := req.Params.UnmarshalSingleParam(0, &txs); err != nil {
+		return nil, err
+	}
+	hashes := make([]string, 0, len(txs))
+	for hash := range txs {
+		hashes = append(hashes, hash)
+	}
+	return hashes, nil
+}
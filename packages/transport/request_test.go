@@ -0,0 +1,98 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/IBAX-io/go-ibax-sdk/packages/request"
+	"github.com/IBAX-io/go-ibax-sdk/packages/response"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestTransport_SendRequests(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/form":
+			if v := r.Header.Get("Authorization"); v != "Bearer token" {
+				t.Errorf("authorization: want Bearer token, got %s", v)
+			}
+			if err := r.ParseForm(); err != nil || r.PostForm.Get("name") != "value" {
+				t.Errorf("want the form, got %v %v", r.PostForm, err)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"name":"value"}`))
+		case "/files":
+			file, _, err := r.FormFile("hash")
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, _ := io.ReadAll(file)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"data":"` + string(data) + `"}`))
+		case "/rpc":
+			var req request.Request
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatal(err)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			if req.Method == "ibax.missing" {
+				w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"not found"}}`))
+				return
+			}
+			w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":7}`))
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer srv.Close()
+	tr := New(NewOptions())
+	ctx := context.Background()
+
+	var form map[string]string
+	err := tr.SendForm(ctx, Target{Method: http.MethodPost, URL: srv.URL + "/form", Authorization: "Bearer token"},
+		&url.Values{"name": {"value"}}, &form)
+	if err != nil || form["name"] != "value" {
+		t.Errorf("want the form sent back, got %v %v", form, err)
+	}
+
+	var files map[string]string
+	err = tr.SendFiles(ctx, Target{Method: http.MethodPost, URL: srv.URL + "/files"}, map[string][]byte{"hash": []byte("tx")}, &files)
+	if err != nil || files["data"] != "tx" {
+		t.Errorf("want the file sent back, got %v %v", files, err)
+	}
+
+	var result int
+	msg := request.Request{JSONRPC: request.JsonRPCVersion, ID: request.ID{Num: 1}, Method: "ibax.maxBlockId"}
+	if err = tr.SendMessage(ctx, Target{Method: http.MethodPost, URL: srv.URL + "/rpc"}, msg, &result); err != nil || result != 7 {
+		t.Errorf("want the result 7, got %d %v", result, err)
+	}
+	msg.Method = "ibax.missing"
+	var rpcErr *response.RPCError
+	if err = tr.SendMessage(ctx, Target{Method: http.MethodPost, URL: srv.URL + "/rpc"}, msg, nil); !errors.As(err, &rpcErr) {
+		t.Errorf("want the JSON-RPC error, got %v", err)
+	}
+
+	var unauthorized bool
+	err = tr.SendForm(ctx, Target{Method: http.MethodGet, URL: srv.URL + "/denied", Unauthorized: func() {
+		unauthorized = true
+	}}, nil, nil)
+	if !errors.Is(err, response.ErrUnauthorized) || !unauthorized {
+		t.Errorf("want the token rejected, got %v %v", unauthorized, err)
+	}
+}
+
+func TestTxAbsent(t *testing.T) {
+	if absent, err := TxAbsent(nil); absent || err != nil {
+		t.Errorf("want the transaction found, got %v %v", absent, err)
+	}
+	if absent, err := TxAbsent(response.NewHTTPError(http.StatusNotFound, nil)); !absent || err != nil {
+		t.Errorf("want the transaction absent, got %v %v", absent, err)
+	}
+	if _, err := TxAbsent(response.NewHTTPError(http.StatusBadGateway, nil)); err == nil {
+		t.Error("want the status error")
+	}
+}
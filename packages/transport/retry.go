@@ -0,0 +1,208 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"github.com/IBAX-io/go-ibax-sdk/packages/pkg/utils"
+	"math"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy decides whether and when a failed request is sent again
+type RetryPolicy struct {
+	MaxAttempts       int           // total number of attempts including the first one, less than 2 disables retry
+	InitialBackoff    time.Duration // wait time before the second attempt
+	MaxBackoff        time.Duration // upper bound of the wait time
+	Multiplier        float64       // growth factor of the wait time after each attempt
+	Jitter            float64       // random factor in [0,1] applied to each wait time
+	RetryableStatus   []int         // http status codes that are retried
+	RetryableRPCCodes []int         // JSON-RPC error codes that are retried
+}
+
+// DefaultRetryPolicy retries 3 times with exponential backoff from 200ms to 5s
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		RetryableStatus: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryableRPCCodes: []int{
+			-32003, //resource unavailable
+			-32006, //limit exceeded
+		},
+	}
+}
+
+// WithRetryPolicy retry read-only requests and transactions by p, nil disables retry
+func WithRetryPolicy(p *RetryPolicy) Option {
+	return func(o *Options) {
+		o.Retry = p
+	}
+}
+
+// Backoff returns the wait time before the attempt after the n-th one, n starts at 1
+func (p *RetryPolicy) Backoff(n int) time.Duration {
+	if n < 1 {
+		n = 1
+	}
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	d := float64(p.InitialBackoff) * math.Pow(multiplier, float64(n-1))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		d += d * jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(d)
+}
+
+func (p *RetryPolicy) retryableStatus(code int) bool {
+	for _, v := range p.RetryableStatus {
+		if v == code {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *RetryPolicy) retryableRPCCode(code int) bool {
+	for _, v := range p.RetryableRPCCodes {
+		if v == code {
+			return true
+		}
+	}
+	return false
+}
+
+type temporaryError struct {
+	err error
+}
+
+func (e *temporaryError) Error() string {
+	return e.err.Error()
+}
+
+func (e *temporaryError) Unwrap() error {
+	return e.err
+}
+
+// Temporary marks err as a transient failure, the request may be sent again
+func Temporary(err error) error {
+	if err == nil || IsTemporary(err) {
+		return err
+	}
+	return &temporaryError{err: err}
+}
+
+// IsTemporary reports whether err is marked by Temporary
+func IsTemporary(err error) bool {
+	var te *temporaryError
+	return errors.As(err, &te)
+}
+
+func unwrapTemporary(err error) error {
+	if te, ok := err.(*temporaryError); ok {
+		return te.err
+	}
+	return err
+}
+
+// RetryableStatus reports whether the http status code should be retried
+func (t *Transport) RetryableStatus(code int) bool {
+	return t.retry != nil && t.retry.retryableStatus(code)
+}
+
+// RetryableRPCCode reports whether the JSON-RPC error code should be retried
+func (t *Transport) RetryableRPCCode(code int) bool {
+	return t.retry != nil && t.retry.retryableRPCCode(code)
+}
+
+// Retry calls send until it succeeds, returns an error not marked by Temporary or the attempts are used up.
+// send must be a read-only request, it is called with the endpoint picked from the pool, or an empty string
+// if no pool is set. When a pool is set, a temporary failure is sent to the next healthy endpoint at once
+func (t *Transport) Retry(ctx context.Context, send func(endpoint string) error) error {
+	return t.do(ctx, false, send, nil)
+}
+
+// RetryTx is like Retry but for a request sending transactions. Before sending again,
+// absent must confirm the node at the failed endpoint has not received the transactions,
+// if it has, the transactions are considered sent and nil is returned
+func (t *Transport) RetryTx(ctx context.Context, send func(endpoint string) error, absent func(endpoint string) (bool, error)) error {
+	return t.do(ctx, true, send, absent)
+}
+
+func (t *Transport) do(ctx context.Context, sendTx bool, send func(endpoint string) error, absent func(endpoint string) (bool, error)) error {
+	attempts := 1
+	if t.retry != nil && t.retry.MaxAttempts > 1 {
+		attempts = t.retry.MaxAttempts
+	}
+	var failovers int
+	if t.pool != nil {
+		failovers = t.pool.Len() - 1
+	}
+	var (
+		err      error
+		reauthed bool
+	)
+	for n := 1; ; {
+		var endpoint string
+		if t.pool != nil {
+			endpoint = t.pool.Pick()
+		}
+		gen := t.session()
+		err = t.limited(ctx, endpoint, sendTx, send)
+		if !reauthed && t.canReauth(ctx, err) {
+			reauthed = true
+			if er := t.loginAgain(ctx, endpoint, gen, err); er != nil {
+				return er
+			}
+			continue
+		}
+		if err == nil || !IsTemporary(err) {
+			break
+		}
+		if t.pool != nil {
+			t.pool.Fail(endpoint, err)
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		if failovers > 0 && t.pool.healthy() > 0 {
+			failovers--
+		} else {
+			if n >= attempts {
+				break
+			}
+			if er := utils.Sleep(ctx, t.retry.Backoff(n)); er != nil {
+				break
+			}
+			n++
+		}
+		t.logger.Warn("request failed, sending again", "endpoint", endpoint, "attempt", n, "error", unwrapTemporary(err))
+		t.metrics.IncRetry(callMethod(ctx), t.endpoint(endpoint))
+		if absent != nil {
+			ok, er := absent(endpoint)
+			if er != nil {
+				break
+			}
+			if !ok {
+				return nil
+			}
+		}
+	}
+	return unwrapTemporary(err)
+}
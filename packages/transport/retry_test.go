@@ -0,0 +1,101 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newRetryTransport() *Transport {
+	p := DefaultRetryPolicy()
+	p.InitialBackoff = time.Millisecond
+	p.MaxBackoff = 2 * time.Millisecond
+	return New(NewOptions(WithRetryPolicy(p)))
+}
+
+func TestTransport_Retry(t *testing.T) {
+	tr := newRetryTransport()
+	failure := errors.New("503 Service Unavailable")
+
+	var calls int
+	err := tr.Retry(context.Background(), func(string) error {
+		calls++
+		if calls < 3 {
+			return Temporary(failure)
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Errorf("want success after 3 calls, got %d calls, err %v", calls, err)
+	}
+
+	calls = 0
+	err = tr.Retry(context.Background(), func(string) error {
+		calls++
+		return Temporary(failure)
+	})
+	if err != failure || calls != 3 {
+		t.Errorf("want the unwrapped failure after 3 calls, got %d calls, err %v", calls, err)
+	}
+
+	calls = 0
+	err = tr.Retry(context.Background(), func(string) error {
+		calls++
+		return failure
+	})
+	if err != failure || calls != 1 {
+		t.Errorf("permanent failure must not be retried, got %d calls", calls)
+	}
+}
+
+func TestTransport_RetryTx(t *testing.T) {
+	tr := newRetryTransport()
+	failure := errors.New("connection reset")
+
+	var calls int
+	err := tr.RetryTx(context.Background(), func(string) error {
+		calls++
+		return Temporary(failure)
+	}, func(string) (bool, error) {
+		return false, nil
+	})
+	if err != nil || calls != 1 {
+		t.Errorf("received transaction must not be sent again, got %d calls, err %v", calls, err)
+	}
+
+	calls = 0
+	err = tr.RetryTx(context.Background(), func(string) error {
+		calls++
+		return Temporary(failure)
+	}, func(string) (bool, error) {
+		return false, errors.New("txstatus failed")
+	})
+	if err != failure || calls != 1 {
+		t.Errorf("unconfirmed transaction must not be sent again, got %d calls, err %v", calls, err)
+	}
+
+	calls = 0
+	err = tr.RetryTx(context.Background(), func(string) error {
+		calls++
+		if calls < 2 {
+			return Temporary(failure)
+		}
+		return nil
+	}, func(string) (bool, error) {
+		return true, nil
+	})
+	if err != nil || calls != 2 {
+		t.Errorf("absent transaction must be sent again, got %d calls, err %v", calls, err)
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := &RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 2}
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second}
+	for i, w := range want {
+		if d := p.Backoff(i + 1); d != w {
+			t.Errorf("backoff %d: want %s, got %s", i+1, w, d)
+		}
+	}
+}
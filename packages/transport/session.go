@@ -0,0 +1,177 @@
+package transport
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultRefreshAhead is the time before the token expiry the session keeper logs in again, see WithTokenRefresh
+const DefaultRefreshAhead = 10 * time.Minute
+
+// refresh backoff of the session keeper after a failed login
+const (
+	refreshRetryMin = 5 * time.Second
+	refreshRetryMax = time.Minute
+)
+
+// refreshMinDelay is the least wait of the session keeper before a refresh, so an expired token is not refreshed in a loop
+const refreshMinDelay = time.Second
+
+// WithTokenRefresh starts a session keeper, it logs in again ahead of the token expiry, DefaultRefreshAhead if
+// ahead is not greater than zero. Only the sessions started by a login are kept, a failed login is tried again
+// with backoff until it succeeds or the client is closed
+func WithTokenRefresh(ahead time.Duration) Option {
+	return func(o *Options) {
+		if ahead <= 0 {
+			ahead = DefaultRefreshAhead
+		}
+		o.RefreshAhead = ahead
+	}
+}
+
+// OnTokenRefreshed sets the callback of a successful login again, by the session keeper or WithReauth.
+// expire is the expiry of the new token, zero if unknown
+func OnTokenRefreshed(f func(expire time.Time)) Option {
+	return func(o *Options) {
+		o.OnTokenRefreshed = f
+	}
+}
+
+// OnAuthFailed sets the callback of a failed login again, by the session keeper or WithReauth
+func OnAuthFailed(f func(err error)) Option {
+	return func(o *Options) {
+		o.OnAuthFailed = f
+	}
+}
+
+// keeper refreshes the token of a client in the background
+type keeper struct {
+	ahead       time.Duration
+	onRefreshed func(expire time.Time)
+	onFailed    func(err error)
+
+	expiry func() time.Time // expiry of the current token, zero if there is none
+	wake   chan struct{}
+	cancel context.CancelFunc
+	done   chan struct{}
+	once   sync.Once
+}
+
+// KeepSession sets the token expiry of the client, and starts the session keeper if WithTokenRefresh is set.
+// It is called by the client constructors
+func (t *Transport) KeepSession(expiry func() time.Time) {
+	t.keeper.expiry = expiry
+	if t.keeper.ahead <= 0 {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.keeper.wake = make(chan struct{}, 1)
+	t.keeper.cancel = cancel
+	t.keeper.done = make(chan struct{})
+	go t.keep(ctx)
+}
+
+// TokenChanged wakes the session keeper to track the expiry of a new token
+func (t *Transport) TokenChanged() {
+	select {
+	case t.keeper.wake <- struct{}{}:
+	default:
+	}
+}
+
+// stopKeeper stops the session keeper and waits for it
+func (t *Transport) stopKeeper() {
+	t.keeper.once.Do(func() {
+		if t.keeper.cancel != nil {
+			t.keeper.cancel()
+			<-t.keeper.done
+		}
+	})
+}
+
+func (t *Transport) keep(ctx context.Context) {
+	defer close(t.keeper.done)
+	var retry time.Duration
+	for {
+		var (
+			timer *time.Timer
+			wait  <-chan time.Time
+		)
+		switch expire := t.keeper.expiry(); {
+		case retry > 0:
+			timer = time.NewTimer(retry)
+		case !expire.IsZero():
+			timer = time.NewTimer(refreshDelay(time.Until(expire), t.keeper.ahead))
+		}
+		if timer != nil {
+			wait = timer.C
+		}
+		select {
+		case <-ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+		case <-t.keeper.wake:
+			if timer != nil {
+				timer.Stop()
+			}
+			continue
+		case <-wait:
+		}
+		if err := t.refresh(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			t.logger.Warn("token refresh failed", "error", err)
+			if retry *= 2; retry < refreshRetryMin {
+				retry = refreshRetryMin
+			} else if retry > refreshRetryMax {
+				retry = refreshRetryMax
+			}
+			continue
+		}
+		retry = 0
+	}
+}
+
+// refreshDelay returns the wait before the refresh of a token expiring in remaining. A token living less than twice
+// ahead is refreshed at the half of its remaining lifetime, so the tokens shorter than ahead are not refreshed at once
+func refreshDelay(remaining, ahead time.Duration) time.Duration {
+	d := remaining - ahead
+	if d < remaining/2 {
+		d = remaining / 2
+	}
+	if d < refreshMinDelay {
+		d = refreshMinDelay
+	}
+	return d
+}
+
+// refresh logs in again, the requests rejected meanwhile do not log in again
+func (t *Transport) refresh(ctx context.Context) error {
+	t.reauth.lock.Lock()
+	err := t.login(ctx)
+	t.reauth.lock.Unlock()
+	t.loggedIn(err)
+	return err
+}
+
+// loggedIn reports the result of a login again to the metrics and the callbacks
+func (t *Transport) loggedIn(err error) {
+	t.metrics.IncTokenRefresh(err)
+	if err != nil {
+		if t.keeper.onFailed != nil {
+			t.keeper.onFailed(err)
+		}
+		return
+	}
+	if t.keeper.onRefreshed != nil {
+		var expire time.Time
+		if t.keeper.expiry != nil {
+			expire = t.keeper.expiry()
+		}
+		t.keeper.onRefreshed(expire)
+	}
+}
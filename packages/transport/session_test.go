@@ -0,0 +1,23 @@
+package transport
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRefreshDelay(t *testing.T) {
+	tests := []struct {
+		remaining, ahead, want time.Duration
+	}{
+		{time.Hour, 10 * time.Minute, 50 * time.Minute},
+		{15 * time.Minute, 10 * time.Minute, 7*time.Minute + 30*time.Second},
+		{5 * time.Minute, 10 * time.Minute, 150 * time.Second},
+		{time.Second, 10 * time.Minute, time.Second},
+		{-time.Minute, 10 * time.Minute, time.Second},
+	}
+	for _, tt := range tests {
+		if got := refreshDelay(tt.remaining, tt.ahead); got != tt.want {
+			t.Errorf("refreshDelay(%s, %s) = %s, want %s", tt.remaining, tt.ahead, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package transport
+
+import (
+	"github.com/IBAX-io/go-ibax-sdk/packages/tracing"
+)
+
+// WithTracer sets the tracer of the client. The high level calls start a span with child spans for each step,
+// the trace context is sent to the node in the traceparent header of the http requests, the calls over a
+// websocket connection carry none
+func WithTracer(t tracing.Tracer) Option {
+	return func(o *Options) {
+		o.Tracer = t
+	}
+}
+
+// Tracer returns the tracer of the client, tracing.Nop if it is not set
+func (t *Transport) Tracer() tracing.Tracer {
+	return t.tracer
+}
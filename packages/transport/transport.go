@@ -0,0 +1,119 @@
+package transport
+
+import (
+	"github.com/IBAX-io/go-ibax-sdk/packages/logger"
+	"github.com/IBAX-io/go-ibax-sdk/packages/metrics"
+	"github.com/IBAX-io/go-ibax-sdk/packages/tracing"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Transport sends the http requests of a client
+type Transport struct {
+	client    *http.Client
+	userAgent string
+	header    http.Header
+	retry     *RetryPolicy
+	pool      *Pool
+	keepAlive time.Duration
+	limiter   *limiter
+	logger    logger.Logger
+	metrics   metrics.Metrics
+	tracer    tracing.Tracer
+	address   string
+
+	reauthEnabled bool
+	reauth        reauth
+	keeper        keeper
+	middlewares   []Middleware
+
+	wsLock  sync.Mutex
+	sockets map[string]*WebSocket
+}
+
+// New creates a Transport from opts
+func New(opts *Options) *Transport {
+	if opts == nil {
+		opts = &Options{}
+	}
+	var client http.Client
+	if opts.HTTPClient != nil {
+		client = *opts.HTTPClient
+	}
+	if opts.RoundTripper != nil {
+		client.Transport = opts.RoundTripper
+	}
+	if opts.Timeout > 0 {
+		client.Timeout = opts.Timeout
+	}
+	return &Transport{
+		client:    &client,
+		userAgent: opts.UserAgent,
+		header:    opts.Header.Clone(),
+		retry:     opts.Retry,
+		keepAlive: opts.KeepAlive,
+		limiter:   newLimiter(opts.ReadLimit, opts.SendTxLimit),
+		logger:    logger.OrNop(opts.Logger),
+		metrics:   metrics.OrNop(opts.Metrics),
+		tracer:    tracing.OrNop(opts.Tracer),
+
+		reauthEnabled: opts.Reauth,
+		keeper:        keeper{ahead: opts.RefreshAhead, onRefreshed: opts.OnTokenRefreshed, onFailed: opts.OnAuthFailed},
+		middlewares:   append([]Middleware(nil), opts.Middlewares...),
+	}
+}
+
+// SetPool routes the requests sent by Retry and RetryTx to the endpoints of p
+func (t *Transport) SetPool(p *Pool) {
+	t.pool = p
+}
+
+// SetAddress sets the api address the requests are sent to if no pool is set, it names the endpoint of the metrics
+func (t *Transport) SetAddress(address string) {
+	t.address = address
+}
+
+// Pool returns the node endpoint pool, nil if not set
+func (t *Transport) Pool() *Pool {
+	return t.pool
+}
+
+// Do sends req with the configured user agent, the extra headers, the headers set by the middlewares and the
+// trace context of the span of the request context. Headers already set on req are not overwritten, network
+// failures are marked by Temporary
+func (t *Transport) Do(req *http.Request) (*http.Response, error) {
+	addHeader(req.Header, callHeader(req.Context()))
+	addHeader(req.Header, t.header)
+	if req.Header.Get(tracing.TraceParentHeader) == "" {
+		tracing.Inject(req.Context(), req.Header)
+	}
+	if t.userAgent != "" {
+		req.Header.Set("User-Agent", t.userAgent)
+	}
+	debug := logger.DebugEnabled(t.logger)
+	if debug {
+		t.debugRequest(req)
+	}
+	start := time.Now()
+	resp, err := t.client.Do(req)
+	if debug {
+		t.debugResponse(req, resp, err, time.Since(start))
+	}
+	if err != nil && req.Context().Err() == nil {
+		return nil, Temporary(err)
+	}
+	return resp, err
+}
+
+// addHeader adds the values of src whose key is not set in dst
+func addHeader(dst, src http.Header) {
+	for key, values := range src {
+		if _, ok := dst[key]; ok {
+			continue
+		}
+		for _, v := range values {
+			dst.Add(key, v)
+		}
+	}
+}
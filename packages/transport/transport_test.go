@@ -0,0 +1,44 @@
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTransport_Do(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ua := r.Header.Get("User-Agent"); ua != "sdk-test" {
+			t.Errorf("user agent: want sdk-test, got %s", ua)
+		}
+		if v := r.Header.Get("X-Request-Source"); v != "unit" {
+			t.Errorf("extra header: want unit, got %s", v)
+		}
+		if v := r.Header.Get("Content-Type"); v != "application/json" {
+			t.Errorf("content type must not be overwritten, got %s", v)
+		}
+	}))
+	defer srv.Close()
+
+	tr := New(NewOptions(
+		WithHTTPClient(&http.Client{}),
+		WithTimeout(time.Second),
+		WithUserAgent("sdk-test"),
+		WithHeader("X-Request-Source", "unit"),
+		WithHeader("Content-Type", "text/plain"),
+	))
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := tr.Do(req)
+	if err != nil {
+		t.Fatalf("do request failed: %s", err.Error())
+	}
+	resp.Body.Close()
+	if tr.client.Timeout != time.Second {
+		t.Errorf("timeout: want %s, got %s", time.Second, tr.client.Timeout)
+	}
+}
@@ -0,0 +1,319 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/IBAX-io/go-ibax-sdk/packages/logger"
+	"github.com/gorilla/websocket"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultKeepAlive is the ping interval of a websocket connection
+const DefaultKeepAlive = 30 * time.Second
+
+// ErrWebSocketClosed is returned by the calls of a closed WebSocket
+var ErrWebSocketClosed = errors.New("websocket closed")
+
+// WithKeepAlive set the ping interval of the websocket connections, a negative d disables ping
+func WithKeepAlive(d time.Duration) Option {
+	return func(o *Options) {
+		o.KeepAlive = d
+	}
+}
+
+// IsWebSocket reports whether endpoint is a ws:// or wss:// address
+func IsWebSocket(endpoint string) bool {
+	return strings.HasPrefix(endpoint, "ws://") || strings.HasPrefix(endpoint, "wss://")
+}
+
+type wsResult struct {
+	data []byte
+	err  error
+}
+
+// WebSocket is a persistent JSON-RPC connection to a node. Concurrent calls share the connection,
+// responses are matched to the calls by request id. A broken connection fails the pending calls
+// with a temporary error and is dialed again by the next call
+type WebSocket struct {
+	t         *Transport
+	url       string
+	keepAlive time.Duration
+	dialer    *websocket.Dialer
+
+	lock    sync.Mutex
+	conn    *websocket.Conn
+	auth    string // Authorization header of conn
+	pending map[string]chan wsResult
+	closed  bool
+
+	writeLock sync.Mutex
+}
+
+// WebSocket returns the connection to url, created on the first call
+func (t *Transport) WebSocket(url string) *WebSocket {
+	t.wsLock.Lock()
+	defer t.wsLock.Unlock()
+	if t.sockets == nil {
+		t.sockets = make(map[string]*WebSocket)
+	}
+	w, ok := t.sockets[url]
+	if !ok {
+		w = &WebSocket{
+			t:         t,
+			url:       url,
+			keepAlive: t.keepAlive,
+			dialer: &websocket.Dialer{
+				Proxy:            http.ProxyFromEnvironment,
+				HandshakeTimeout: t.client.Timeout,
+			},
+			pending: make(map[string]chan wsResult),
+		}
+		t.sockets[url] = w
+	}
+	return w
+}
+
+// Close stops the session keeper and closes all websocket connections
+func (t *Transport) Close() error {
+	t.stopKeeper()
+	t.wsLock.Lock()
+	defer t.wsLock.Unlock()
+	for url, w := range t.sockets {
+		w.Close()
+		delete(t.sockets, url)
+	}
+	return nil
+}
+
+// Call writes the JSON-RPC message body and returns the response to the request ids.
+// ids are the json encoded ids of body, the first one identifies the response of a batch.
+// authorization is sent in the handshake, the connection is dialed again when it changes
+func (w *WebSocket) Call(ctx context.Context, ids []string, authorization string, body []byte) ([]byte, error) {
+	if len(ids) == 0 {
+		return nil, errors.New("websocket call without request id")
+	}
+	conn, err := w.connect(ctx, authorization)
+	if err != nil {
+		return nil, err
+	}
+	ch := make(chan wsResult, 1)
+	w.lock.Lock()
+	for _, id := range ids {
+		if _, ok := w.pending[id]; ok {
+			w.lock.Unlock()
+			return nil, errors.New("duplicate request id " + id)
+		}
+	}
+	for _, id := range ids {
+		w.pending[id] = ch
+	}
+	w.lock.Unlock()
+	defer w.release(ids)
+
+	debug := logger.DebugEnabled(w.t.logger)
+	if debug {
+		w.t.logger.Debug("send websocket message", "url", w.url, "body", cutBody(logger.RedactBody("application/json", body)))
+	}
+	if err = w.write(ctx, conn, websocket.TextMessage, body); err != nil {
+		w.broken(conn, err)
+		return nil, Temporary(err)
+	}
+	select {
+	case r := <-ch:
+		if debug && r.err == nil {
+			w.t.logger.Debug("receive websocket message", "url", w.url, "body", cutBody(logger.RedactBody("application/json", r.data)))
+		}
+		return r.data, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Close closes the connection, the pending calls fail with ErrWebSocketClosed
+func (w *WebSocket) Close() error {
+	w.lock.Lock()
+	w.closed = true
+	conn := w.conn
+	w.lock.Unlock()
+	if conn == nil {
+		return nil
+	}
+	w.broken(conn, ErrWebSocketClosed)
+	return nil
+}
+
+func (w *WebSocket) connect(ctx context.Context, authorization string) (*websocket.Conn, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.closed {
+		return nil, ErrWebSocketClosed
+	}
+	if w.conn != nil && w.auth == authorization {
+		return w.conn, nil
+	}
+	if w.conn != nil {
+		// the pending calls of the replaced connection are failed and sent again
+		w.drop(errors.New("websocket authorization changed"))
+	}
+	header := make(http.Header)
+	addHeader(header, w.t.header)
+	if w.t.userAgent != "" {
+		header.Set("User-Agent", w.t.userAgent)
+	}
+	if authorization != "" {
+		header.Set("Authorization", authorization)
+	}
+	conn, resp, err := w.dialer.DialContext(ctx, w.url, header)
+	if err != nil {
+		if resp != nil && !w.t.RetryableStatus(resp.StatusCode) {
+			return nil, err
+		}
+		if ctx.Err() == nil {
+			return nil, Temporary(err)
+		}
+		return nil, err
+	}
+	w.conn = conn
+	w.auth = authorization
+	go w.read(conn)
+	if w.keepAlive >= 0 {
+		go w.ping(conn)
+	}
+	return conn, nil
+}
+
+func (w *WebSocket) interval() time.Duration {
+	if w.keepAlive > 0 {
+		return w.keepAlive
+	}
+	return DefaultKeepAlive
+}
+
+// read dispatches the responses until conn is broken
+func (w *WebSocket) read(conn *websocket.Conn) {
+	if w.keepAlive >= 0 {
+		wait := 2 * w.interval()
+		conn.SetReadDeadline(time.Now().Add(wait))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(wait))
+		})
+	}
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			w.broken(conn, err)
+			return
+		}
+		if w.keepAlive >= 0 {
+			conn.SetReadDeadline(time.Now().Add(2 * w.interval()))
+		}
+		w.dispatch(data)
+	}
+}
+
+// dispatch delivers data to the call waiting for its id, messages without a known id are dropped
+func (w *WebSocket) dispatch(data []byte) {
+	type message struct {
+		ID json.RawMessage `json:"id"`
+	}
+	var ids []json.RawMessage
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		var list []message
+		if err := json.Unmarshal(trimmed, &list); err != nil {
+			return
+		}
+		for _, m := range list {
+			ids = append(ids, m.ID)
+		}
+	} else {
+		var m message
+		if err := json.Unmarshal(trimmed, &m); err != nil {
+			return
+		}
+		ids = append(ids, m.ID)
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	for _, id := range ids {
+		if ch, ok := w.pending[string(id)]; ok {
+			select {
+			case ch <- wsResult{data: data}:
+			default:
+			}
+			return
+		}
+	}
+}
+
+func (w *WebSocket) ping(conn *websocket.Conn) {
+	ticker := time.NewTicker(w.interval())
+	defer ticker.Stop()
+	for range ticker.C {
+		w.lock.Lock()
+		current := w.conn == conn
+		w.lock.Unlock()
+		if !current {
+			return
+		}
+		w.writeLock.Lock()
+		err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(w.interval()))
+		w.writeLock.Unlock()
+		if err != nil {
+			w.broken(conn, err)
+			return
+		}
+	}
+}
+
+func (w *WebSocket) write(ctx context.Context, conn *websocket.Conn, messageType int, data []byte) error {
+	w.writeLock.Lock()
+	defer w.writeLock.Unlock()
+	deadline, ok := ctx.Deadline()
+	if !ok && w.t.client.Timeout > 0 {
+		deadline = time.Now().Add(w.t.client.Timeout)
+	}
+	conn.SetWriteDeadline(deadline)
+	return conn.WriteMessage(messageType, data)
+}
+
+// broken closes conn and fails the calls waiting on it
+func (w *WebSocket) broken(conn *websocket.Conn, err error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.conn != conn {
+		return
+	}
+	w.drop(err)
+}
+
+// drop closes the current connection and fails all pending calls, w.lock must be held
+func (w *WebSocket) drop(err error) {
+	if err != ErrWebSocketClosed {
+		w.t.logger.Warn("websocket connection broken", "url", w.url, "error", err)
+	}
+	w.conn.Close()
+	w.conn = nil
+	if err != ErrWebSocketClosed {
+		err = Temporary(err)
+	}
+	for id, ch := range w.pending {
+		select {
+		case ch <- wsResult{err: err}:
+		default:
+		}
+		delete(w.pending, id)
+	}
+}
+
+func (w *WebSocket) release(ids []string) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	for _, id := range ids {
+		delete(w.pending, id)
+	}
+}
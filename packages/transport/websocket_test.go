@@ -0,0 +1,101 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gorilla/websocket"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newEchoServer replies to every JSON-RPC request with its id as the result,
+// the replies are delayed by the request id so they arrive out of order
+func newEchoServer(t *testing.T, dials *int32) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(dials, 1)
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.Close()
+		var lock sync.Mutex
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req struct {
+				ID uint64 `json:"id"`
+			}
+			json.Unmarshal(data, &req)
+			if req.ID == 0 {
+				// the server drops the connection
+				return
+			}
+			go func() {
+				time.Sleep(time.Duration(10-req.ID) * time.Millisecond)
+				lock.Lock()
+				defer lock.Unlock()
+				conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":%d}`, req.ID, req.ID)))
+			}()
+		}
+	}))
+}
+
+func TestWebSocket_Call(t *testing.T) {
+	var dials int32
+	srv := newEchoServer(t, &dials)
+	defer srv.Close()
+
+	tr := New(NewOptions(WithTimeout(time.Second)))
+	defer tr.Close()
+	w := tr.WebSocket("ws" + strings.TrimPrefix(srv.URL, "http"))
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 5; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			data, err := w.Call(context.Background(), []string{fmt.Sprint(id)}, "", []byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":%d}`, id)))
+			if err != nil {
+				t.Errorf("call %d: %s", id, err)
+				return
+			}
+			var resp struct {
+				Result int `json:"result"`
+			}
+			if json.Unmarshal(data, &resp); resp.Result != id {
+				t.Errorf("call %d got the response %s", id, data)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if atomic.LoadInt32(&dials) != 1 {
+		t.Errorf("want the calls to share 1 connection, got %d", dials)
+	}
+
+	_, err := w.Call(context.Background(), []string{"0"}, "", []byte(`{"jsonrpc":"2.0","id":0}`))
+	if !IsTemporary(err) {
+		t.Errorf("want a temporary error on a dropped connection, got %v", err)
+	}
+	if _, err = w.Call(context.Background(), []string{"1"}, "", []byte(`{"jsonrpc":"2.0","id":1}`)); err != nil {
+		t.Errorf("want the call to reconnect, got %v", err)
+	}
+	if atomic.LoadInt32(&dials) != 2 {
+		t.Errorf("want 2 connections, got %d", dials)
+	}
+
+	w.Close()
+	if _, err = w.Call(context.Background(), []string{"1"}, "", nil); !errors.Is(err, ErrWebSocketClosed) {
+		t.Errorf("want ErrWebSocketClosed, got %v", err)
+	}
+}